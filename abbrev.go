@@ -0,0 +1,82 @@
+package netaddr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseAbbreviatedCIDR parses an abbreviated IPv4 CIDR, e.g. "10/8",
+// "192.168/16" or just "10", expanding it following the rules of Python
+// netaddr's cidr_abbrev_to_verbose. Missing trailing octets are taken to be
+// zero and, when no prefix length is given, it is derived from the legacy
+// classful scheme using the first octet:
+//
+//	0-127:   class A, /8
+//	128-191: class B, /16
+//	192-223: class C, /24
+//	224-239: multicast, /4
+//	240-255: /32
+//
+// An error is returned for IPv6 input, which has no classful shorthand.
+//
+// Example usage:
+//
+//	nw, err := netaddr.ParseAbbreviatedCIDR("10")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(nw) // Output: "10.0.0.0/8"
+func ParseAbbreviatedCIDR(s string) (*IPNetwork, error) {
+	if strings.Contains(s, ":") {
+		return nil, fmt.Errorf("invalid abbreviated cidr %q: abbreviations are only supported for IPv4", s)
+	}
+
+	addr, prefix, hasPrefix := strings.Cut(s, "/")
+	octets := strings.Split(addr, ".")
+	if len(octets) > IPv4len {
+		return nil, fmt.Errorf("invalid abbreviated cidr %q: expected at most %d octets", s, IPv4len)
+	}
+
+	values := make([]string, IPv4len)
+	for i := range values {
+		values[i] = "0"
+	}
+	var firstOctet uint64
+	for i, octet := range octets {
+		value, err := strconv.ParseUint(octet, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid abbreviated cidr %q: octet %q is not a number between 0 and 255", s, octet)
+		}
+		if i == 0 {
+			firstOctet = value
+		}
+		values[i] = strconv.FormatUint(value, 10)
+	}
+
+	if hasPrefix {
+		if length, err := strconv.Atoi(prefix); err != nil || length < 0 || length > IPv4len*8 {
+			return nil, fmt.Errorf("invalid abbreviated cidr %q: prefix %q is not a number between 0 and %d", s, prefix, IPv4len*8)
+		}
+	} else {
+		prefix = strconv.Itoa(classfulPrefix(firstOctet))
+	}
+
+	return NewIPNetwork(strings.Join(values, ".") + "/" + prefix)
+}
+
+// classfulPrefix returns the prefix length of the legacy classful network
+// whose first octet is octet.
+func classfulPrefix(octet uint64) int {
+	switch {
+	case octet <= 127:
+		return 8
+	case octet <= 191:
+		return 16
+	case octet <= 223:
+		return 24
+	case octet <= 239:
+		return 4
+	}
+	return 32
+}
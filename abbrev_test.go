@@ -0,0 +1,49 @@
+package netaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAbbreviatedCIDR(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		abbrev   string
+		expected string
+		wantErr  bool
+	}{
+		{"10", "10.0.0.0/8", false},
+		{"127", "127.0.0.0/8", false},
+		{"128", "128.0.0.0/16", false},
+		{"191.254", "191.254.0.0/16", false},
+		{"192", "192.0.0.0/24", false},
+		{"192.168.1", "192.168.1.0/24", false},
+		{"224", "224.0.0.0/4", false},
+		{"240", "240.0.0.0/32", false},
+		{"10/8", "10.0.0.0/8", false},
+		{"192.168/16", "192.168.0.0/16", false},
+		{"10.1.2.3/32", "10.1.2.3/32", false},
+		{"2001:db8::/32", "", true},
+		{"::1", "", true},
+		{"256", "", true},
+		{"10.0.0.0.0", "", true},
+		{"10/33", "", true},
+		{"10/", "", true},
+		{"a.b", "", true},
+		{"", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.abbrev, func(t *testing.T) {
+			nw, err := ParseAbbreviatedCIDR(test.abbrev)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, nw.String())
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package netaddr
+
+// Addressable is implemented by IPAddress, IPNetwork and IPRange, the types
+// describing a contiguous block of addresses, so they can be handled and
+// sorted together.
+type Addressable interface {
+	// FirstInt returns the integer value of the first address of the block.
+	FirstInt() *IPNumber
+	// LastInt returns the integer value of the last address of the block.
+	LastInt() *IPNumber
+	// Version returns the IP version of the block.
+	Version() *Version
+}
+
+// ByAddressable is a type that implements sort.Interface for sorting a mixed
+// slice of addresses, networks and ranges. It sorts IPv4 before IPv6, then by
+// first address and finally by last address, so a block sorts before any
+// larger block starting at the same address.
+//
+// Example usage:
+//
+//	items := netaddr.ByAddressable{nw, ip, ipRange}
+//	sort.Sort(items)
+type ByAddressable []Addressable
+
+// Len returns the number of items in the slice. It is required by sort.Interface.
+func (as ByAddressable) Len() int {
+	return len(as)
+}
+
+// Less reports whether the item at index i should sort before the item at
+// index j. It is required by sort.Interface.
+func (as ByAddressable) Less(i, j int) bool {
+	a, b := as[i], as[j]
+	if va, vb := a.Version(), b.Version(); va != vb {
+		return va == nil || (vb != nil && va.LessThan(vb))
+	}
+	if c := a.FirstInt().cmp(b.FirstInt()); c != 0 {
+		return c < 0
+	}
+	return a.LastInt().LessThan(b.LastInt())
+}
+
+// Swap exchanges the items at indices i and j. It is required by sort.Interface.
+func (as ByAddressable) Swap(i, j int) {
+	as[i], as[j] = as[j], as[i]
+}
@@ -0,0 +1,43 @@
+package netaddr
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByAddressable(t *testing.T) {
+	t.Parallel()
+
+	r, err := newIPRange(NewIP("10.0.0.5"), NewIP("10.0.1.9"))
+	assert.NoError(t, err)
+	wide, err := newIPRange(NewIP("10.0.0.0"), NewIP("10.0.0.200"))
+	assert.NoError(t, err)
+
+	items := ByAddressable{
+		NewIP("2001:db8::1"),
+		r,
+		newTestNetwork(t, "10.0.0.0/24"),
+		NewIP("10.0.0.7"),
+		newTestNetwork(t, "2001:db8::/32"),
+		wide,
+		NewIP("10.0.0.0"),
+	}
+	sort.Sort(items)
+
+	var sorted []string
+	for _, item := range items {
+		sorted = append(sorted, fmt.Sprintf("%T %d-%d", item, item.FirstInt(), item.LastInt()))
+	}
+	assert.Equal(t, []string{
+		"*netaddr.IPAddress 167772160-167772160",
+		"*netaddr.IPRange 167772160-167772360",
+		"*netaddr.IPNetwork 167772160-167772415",
+		"*netaddr.IPRange 167772165-167772425",
+		"*netaddr.IPAddress 167772167-167772167",
+		"*netaddr.IPNetwork 42540766411282592856903984951653826560-42540766490510755371168322545197776895",
+		"*netaddr.IPAddress 42540766411282592856903984951653826561-42540766411282592856903984951653826561",
+	}, sorted)
+}
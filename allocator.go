@@ -0,0 +1,213 @@
+package netaddr
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+var (
+	// ErrorPoolExhausted is an error returned when an Allocator has no free
+	// block large enough to satisfy a request.
+	ErrorPoolExhausted = fmt.Errorf("no free block large enough in allocator pools")
+)
+
+// Strategy selects which free block an Allocator carves an allocation from.
+type Strategy int
+
+const (
+	// FirstFit allocates from the first free block, in address order, that is
+	// large enough.
+	FirstFit Strategy = iota
+	// BestFit allocates from the smallest free block that is large enough,
+	// preferring the lowest address among equally sized blocks. It keeps
+	// larger blocks intact, reducing fragmentation in long-lived pools.
+	BestFit
+)
+
+// Allocator hands out subnets from a set of pool networks, tracking each
+// allocation and which address space is still free. Released subnets are
+// merged with adjacent free space, so larger blocks become available again.
+type Allocator struct {
+	free IPSet
+	// allocated holds each allocation made, keyed by IPNetwork.Key.
+	allocated map[string]*IPNetwork
+}
+
+// NewAllocator returns an Allocator that allocates subnets from the passed
+// pool networks.
+//
+// Example usage:
+//
+//	pool, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	allocator := netaddr.NewAllocator(pool)
+func NewAllocator(pools ...*IPNetwork) *Allocator {
+	a := &Allocator{allocated: make(map[string]*IPNetwork)}
+	for _, pool := range pools {
+		a.free.Add(pool)
+	}
+	return a
+}
+
+// Allocate returns a block with a prefix length of prefixLen, taken from the
+// start of the free block chosen by strategy, and marks it as allocated.
+// ErrorPoolExhausted is returned if no free block is large enough. If the
+// Allocator holds pools of both IP versions, IPv4 pools are tried first and
+// IPv6 pools only once no IPv4 block fits; use AllocateVersion to choose the
+// version explicitly.
+//
+// Example usage:
+//
+//	pool, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	allocator := netaddr.NewAllocator(pool)
+//	nw, err := allocator.Allocate(26, netaddr.FirstFit)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(nw) // Output: "10.0.0.0/26"
+func (a *Allocator) Allocate(prefixLen int, strategy Strategy) (*IPNetwork, error) {
+	nw, err := a.AllocateVersion(prefixLen, IPv4, strategy)
+	if err == nil {
+		return nw, nil
+	}
+	return a.AllocateVersion(prefixLen, IPv6, strategy)
+}
+
+// AllocateVersion is like Allocate, but only considers pools of the passed IP
+// version, so an Allocator holding both IPv4 and IPv6 pools serves each
+// version separately.
+//
+// Example usage:
+//
+//	pool4, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	pool6, _ := netaddr.NewIPNetwork("2001:db8::/32")
+//	allocator := netaddr.NewAllocator(pool4, pool6)
+//	nw, err := allocator.AllocateVersion(30, netaddr.IPv6, netaddr.FirstFit)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(nw) // Output: "2001:db8::/30"
+func (a *Allocator) AllocateVersion(prefixLen int, version *Version, strategy Strategy) (*IPNetwork, error) {
+	var chosen *IPNetwork
+	for _, block := range a.free {
+		if block.version != version {
+			continue
+		}
+		ones, bits := block.Mask.Size()
+		if prefixLen < ones || prefixLen > bits {
+			continue
+		}
+		if chosen == nil || (strategy == BestFit && ones > chosen.Mask.PrefixLen()) {
+			chosen = block
+		}
+		if strategy == FirstFit {
+			break
+		}
+	}
+	if chosen == nil {
+		return nil, ErrorPoolExhausted
+	}
+
+	_, bits := chosen.Mask.Size()
+	nw := &IPNetwork{
+		start:   chosen.start,
+		version: chosen.version,
+		Mask:    NewMask(int64(prefixLen), int64(bits)),
+	}
+	a.free.Remove(nw)
+	a.allocated[nw.Key()] = nw
+	return nw, nil
+}
+
+// Release returns a previously allocated subnet to the free pool, merging it
+// with any adjacent free blocks. nw must be exactly a network returned by
+// Allocate or AllocateVersion and not yet released; an error is returned for
+// any other network, including a part of an allocation or a block spanning
+// several allocations.
+//
+// Example usage:
+//
+//	pool, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	allocator := netaddr.NewAllocator(pool)
+//	nw, _ := allocator.Allocate(26, netaddr.FirstFit)
+//	if err := allocator.Release(nw); err != nil {
+//	    fmt.Println(err)
+//	}
+func (a *Allocator) Release(nw *IPNetwork) error {
+	key := nw.Key()
+	if _, ok := a.allocated[key]; !ok {
+		return fmt.Errorf("network %s is not allocated", nw)
+	}
+
+	delete(a.allocated, key)
+	a.free.Add(nw)
+	return nil
+}
+
+// VLSM carves subnets out of parent using variable length subnet masking.
+// Each entry of requirements is a number of hosts, and the matching subnet is
+// the smallest block with at least that many assignable addresses, as
+// counted by AssignableCount. Subnets are allocated largest first to avoid
+// fragmentation, but are returned in the order of requirements, along with
+// the space of parent left free. An error wrapping ErrorPoolExhausted is
+// returned if the requirements do not fit in parent.
+//
+// Example usage:
+//
+//	parent, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	subnets, free, err := netaddr.VLSM(parent, []int{120, 60, 20})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(subnets) // Output: [192.168.1.0/25 192.168.1.128/26 192.168.1.192/27]
+//	fmt.Println(free)    // Output: 192.168.1.224/27
+func VLSM(parent *IPNetwork, requirements []int) ([]*IPNetwork, IPSet, error) {
+	prefixLens := make([]int, len(requirements))
+	for i, hosts := range requirements {
+		prefixLen, ok := hostsPrefixLen(hosts, parent.version)
+		if !ok {
+			return nil, nil, fmt.Errorf("requirement %d: invalid host count %d", i, hosts)
+		}
+		prefixLens[i] = prefixLen
+	}
+
+	order := make([]int, len(requirements))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return prefixLens[order[a]] < prefixLens[order[b]]
+	})
+
+	allocator := NewAllocator(parent)
+	subnets := make([]*IPNetwork, len(requirements))
+	for _, i := range order {
+		nw, err := allocator.AllocateVersion(prefixLens[i], parent.version, BestFit)
+		if err != nil {
+			return nil, nil, fmt.Errorf("requirement %d (%d hosts): %w", i, requirements[i], err)
+		}
+		subnets[i] = nw
+	}
+	return subnets, allocator.free, nil
+}
+
+// hostsPrefixLen returns the longest prefix length of the passed version
+// whose networks have at least hosts assignable addresses. ok is false if
+// hosts is not positive or exceeds the address space.
+func hostsPrefixLen(hosts int, version *Version) (prefixLen int, ok bool) {
+	if hosts <= 0 {
+		return 0, false
+	}
+	bits := version.BitLength()
+	want := big.NewInt(int64(hosts))
+	for prefixLen = bits; prefixLen >= 0; prefixLen-- {
+		assignable := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLen))
+		if version == IPv4 && prefixLen < 31 {
+			assignable.Sub(assignable, big.NewInt(2))
+		}
+		if assignable.Cmp(want) >= 0 {
+			return prefixLen, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,144 @@
+package netaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocator(t *testing.T) {
+	t.Parallel()
+
+	allocator := NewAllocator(newTestNetwork(t, "10.0.0.0/24"))
+
+	var allocated []*IPNetwork
+	for _, expected := range []string{"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26"} {
+		nw, err := allocator.Allocate(26, FirstFit)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, nw.String())
+		allocated = append(allocated, nw)
+	}
+
+	// Releasing the middle block makes it the first free /26 again.
+	assert.NoError(t, allocator.Release(allocated[1]))
+	assert.Error(t, allocator.Release(allocated[1]))
+	assert.Equal(t, []string{"10.0.0.64/26", "10.0.0.192/26"}, networkStrings(allocator.free))
+
+	nw, err := allocator.Allocate(26, FirstFit)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.64/26", nw.String())
+
+	_, err = allocator.Allocate(25, FirstFit)
+	assert.ErrorIs(t, err, ErrorPoolExhausted)
+}
+
+func TestAllocatorReleaseCoalesces(t *testing.T) {
+	t.Parallel()
+
+	allocator := NewAllocator(newTestNetwork(t, "10.0.0.0/24"))
+	first, err := allocator.Allocate(25, FirstFit)
+	assert.NoError(t, err)
+	second, err := allocator.Allocate(25, FirstFit)
+	assert.NoError(t, err)
+
+	_, err = allocator.Allocate(24, FirstFit)
+	assert.ErrorIs(t, err, ErrorPoolExhausted)
+
+	// Only exact allocations are released: neither a block spanning both
+	// allocations nor a part of one is accepted.
+	assert.Error(t, allocator.Release(newTestNetwork(t, "10.0.0.0/24")))
+	assert.Error(t, allocator.Release(newTestNetwork(t, "10.0.0.0/26")))
+	assert.Empty(t, allocator.free)
+
+	assert.NoError(t, allocator.Release(first))
+	assert.NoError(t, allocator.Release(second))
+
+	nw, err := allocator.Allocate(24, FirstFit)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/24", nw.String())
+}
+
+func TestAllocatorMultiplePools(t *testing.T) {
+	t.Parallel()
+
+	allocator := NewAllocator(newTestNetwork(t, "10.0.0.0/30"), newTestNetwork(t, "2001:db8::/64"))
+
+	// Allocate prefers IPv4 pools and falls back to IPv6 ones.
+	nw, err := allocator.Allocate(30, FirstFit)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/30", nw.String())
+
+	nw, err = allocator.Allocate(64, FirstFit)
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::/64", nw.String())
+
+	// Each version is only served from pools of that version, whatever the
+	// prefix length or strategy.
+	allocator = NewAllocator(newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "2001::/16"))
+	for _, strategy := range []Strategy{FirstFit, BestFit} {
+		nw, err = allocator.AllocateVersion(30, IPv6, strategy)
+		assert.NoError(t, err)
+		assert.Equal(t, IPv6, nw.version)
+
+		nw, err = allocator.AllocateVersion(30, IPv4, strategy)
+		assert.NoError(t, err)
+		assert.Equal(t, IPv4, nw.version)
+	}
+	_, err = allocator.AllocateVersion(64, IPv4, FirstFit)
+	assert.ErrorIs(t, err, ErrorPoolExhausted)
+
+	assert.Error(t, allocator.Release(newTestNetwork(t, "192.168.0.0/24")))
+}
+
+func TestAllocatorBestFit(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		strategy Strategy
+		expected string
+	}{
+		{FirstFit, "10.0.0.0/28"},
+		{BestFit, "10.0.2.0/28"},
+	}
+
+	for _, test := range tests {
+		allocator := NewAllocator(newTestNetwork(t, "10.0.0.0/25"), newTestNetwork(t, "10.0.1.0/27"), newTestNetwork(t, "10.0.2.0/28"))
+		nw, err := allocator.Allocate(28, test.strategy)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, nw.String())
+	}
+
+	// Best fit prefers the smaller block even when it comes later in address
+	// order, leaving the /25 whole.
+	allocator := NewAllocator(newTestNetwork(t, "10.0.0.0/25"), newTestNetwork(t, "10.0.1.0/27"))
+	nw, err := allocator.Allocate(28, BestFit)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.1.0/28", nw.String())
+	nw, err = allocator.Allocate(25, BestFit)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/25", nw.String())
+}
+
+func TestVLSM(t *testing.T) {
+	t.Parallel()
+
+	parent := newTestNetwork(t, "192.168.1.0/24")
+
+	subnets, free, err := VLSM(parent, []int{120, 60, 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.0/25", "192.168.1.128/26", "192.168.1.192/27"}, networkStrings(subnets))
+	assert.Equal(t, []string{"192.168.1.224/27"}, networkStrings(free))
+
+	// Subnets are returned in requirement order, though allocated largest
+	// first. Point-to-point links fit in a /31 as per RFC 3021.
+	subnets, free, err = VLSM(parent, []int{2, 1, 60})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.64/31", "192.168.1.66/32", "192.168.1.0/26"}, networkStrings(subnets))
+	assert.Equal(t, []string{"192.168.1.67/32", "192.168.1.68/30", "192.168.1.72/29", "192.168.1.80/28", "192.168.1.96/27", "192.168.1.128/25"}, networkStrings(free))
+
+	_, _, err = VLSM(parent, []int{120, 120, 20})
+	assert.ErrorIs(t, err, ErrorPoolExhausted)
+
+	_, _, err = VLSM(parent, []int{0})
+	assert.Error(t, err)
+}
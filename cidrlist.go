@@ -0,0 +1,70 @@
+package netaddr
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseCIDRList reads one CIDR per line from r and returns the parsed
+// networks in order. Blank lines are skipped, as is anything following a "#",
+// so both whole-line and trailing comments are allowed. Errors include the
+// line number of the offending CIDR.
+//
+// Example usage:
+//
+//	f, _ := os.Open("allowlist.txt")
+//	defer f.Close()
+//	networks, err := netaddr.ParseCIDRList(f)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+func ParseCIDRList(r io.Reader) ([]*IPNetwork, error) {
+	var networks []*IPNetwork
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		cidr, _, _ := strings.Cut(scanner.Text(), "#")
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		nw, err := NewIPNetwork(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		networks = append(networks, nw)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return networks, nil
+}
+
+// ParseCIDRs parses a list of CIDRs separated by sep, e.g. ",", returning the
+// networks in order. Whitespace around each CIDR is ignored, as are empty
+// entries. Errors include the position of the offending CIDR in the list,
+// counting from 1.
+//
+// Example usage:
+//
+//	networks, err := netaddr.ParseCIDRs("10.0.0.0/8, 192.168.0.0/16", ",")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(networks) // Output: [10.0.0.0/8 192.168.0.0/16]
+func ParseCIDRs(s string, sep string) ([]*IPNetwork, error) {
+	var networks []*IPNetwork
+	for i, cidr := range strings.Split(s, sep) {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		nw, err := NewIPNetwork(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		networks = append(networks, nw)
+	}
+	return networks, nil
+}
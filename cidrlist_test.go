@@ -0,0 +1,57 @@
+package netaddr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCIDRList(t *testing.T) {
+	t.Parallel()
+
+	blob := `# office networks
+10.0.0.0/8
+
+192.168.1.0/24   # lab
+  2001:db8::/32
+`
+	networks, err := ParseCIDRList(strings.NewReader(blob))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"}, networkStrings(networks))
+
+	_, err = ParseCIDRList(strings.NewReader("10.0.0.0/8\n# comment\nnot a cidr\n"))
+	assert.ErrorContains(t, err, "line 3")
+
+	networks, err = ParseCIDRList(strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Empty(t, networks)
+}
+
+func TestParseCIDRs(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		sep      string
+		expected []string
+		errText  string
+	}{
+		{"10.0.0.0/8,192.168.0.0/16", ",", []string{"10.0.0.0/8", "192.168.0.0/16"}, ""},
+		{"10.0.0.0/8, 192.168.0.0/16 ,", ",", []string{"10.0.0.0/8", "192.168.0.0/16"}, ""},
+		{"10.0.0.0/8 2001:db8::/32", " ", []string{"10.0.0.0/8", "2001:db8::/32"}, ""},
+		{"10.0.0.0/8,bad", ",", nil, "entry 2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			networks, err := ParseCIDRs(test.input, test.sep)
+			if test.errText != "" {
+				assert.ErrorContains(t, err, test.errText)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, networkStrings(networks))
+		})
+	}
+}
@@ -27,9 +27,10 @@
 //
 // To start using the package, import it into your Go application:
 //
-//	import "github.com/mogthesprog/netaddr"
+//	import "github.com/mogthesprog/go-netaddr"
 //
-// Below are some examples to help you get started:
+// Below are some examples to help you get started. They are collected in the
+// package Example, which is compiled and run with the tests:
 //
 // ## Parsing an IP Address
 //
@@ -67,13 +68,23 @@
 //
 // ## Working with IP Ranges
 //
-// You can create an IP range and perform operations on it:
+// You can parse an IP range from its "first-last" text form:
 //
-//	ipRange := netaddr.NewIPRange(netaddr.NewIPAddress("192.168.1.1"), netaddr.NewIPAddress("192.168.1.254"))
+//	var ipRange netaddr.IPRange
+//	if err := ipRange.UnmarshalText([]byte("192.168.1.1-192.168.1.254")); err != nil {
+//		// handle error
+//	}
 //
 // To check if an IP address is within this range:
 //
-//	isInRange := ipRange.Contains(ip)
+//	isInRange := ipRange.ToIPSet().Contains(ip)
+//
+// To list the CIDR blocks covering the range:
+//
+//	cidrs, err := ipRange.ToCIDRs()
+//	if err != nil {
+//		// handle error
+//	}
 //
 // # License
 //
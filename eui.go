@@ -0,0 +1,101 @@
+package netaddr
+
+import (
+	"fmt"
+	"net"
+)
+
+const (
+	// EUI identifier lengths (bytes).
+	EUI48len = 6
+	EUI64len = 8
+)
+
+// EUI represents an IEEE extended unique identifier, either an EUI-48 (MAC)
+// address or an EUI-64 identifier.
+type EUI struct {
+	*net.HardwareAddr
+}
+
+// NewEUI returns a new EUI parsed from eui. The colon (00:11:22:33:44:55),
+// hyphen (00-11-22-33-44-55) and dot (0011.2233.4455) separated forms of both
+// EUI-48 and EUI-64 identifiers are accepted.
+//
+// Example usage:
+//
+//	eui, err := netaddr.NewEUI("00:11:22:33:44:55")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(eui) // Output: "00:11:22:33:44:55"
+func NewEUI(eui string) (*EUI, error) {
+	hw, err := net.ParseMAC(eui)
+	if err != nil {
+		return nil, err
+	}
+	if len(hw) != EUI48len && len(hw) != EUI64len {
+		return nil, fmt.Errorf("%q is not an EUI-48 or EUI-64 identifier", eui)
+	}
+	return &EUI{HardwareAddr: &hw}, nil
+}
+
+// String returns the colon separated representation of the EUI.
+//
+// Example usage:
+//
+//	eui, _ := netaddr.NewEUI("00-11-22-33-44-55")
+//	fmt.Println(eui.String()) // Output: "00:11:22:33:44:55"
+func (e *EUI) String() string {
+	return e.HardwareAddr.String()
+}
+
+// OUI returns the organizationally unique identifier of the EUI, i.e. its
+// first 24 bits, in colon separated form.
+//
+// Example usage:
+//
+//	eui, _ := netaddr.NewEUI("00:11:22:33:44:55")
+//	fmt.Println(eui.OUI()) // Output: "00:11:22"
+func (e *EUI) OUI() string {
+	return (*e.HardwareAddr)[:3].String()
+}
+
+// EUI64 returns the EUI-64 form of the identifier. EUI-48 identifiers are
+// expanded by inserting ff:fe between the OUI and the extension identifier,
+// while EUI-64 identifiers are returned as a copy.
+//
+// Example usage:
+//
+//	eui, _ := netaddr.NewEUI("00:11:22:33:44:55")
+//	fmt.Println(eui.EUI64()) // Output: "00:11:22:ff:fe:33:44:55"
+func (e *EUI) EUI64() *EUI {
+	hw := *e.HardwareAddr
+	expanded := make(net.HardwareAddr, 0, EUI64len)
+	if len(hw) == EUI64len {
+		expanded = append(expanded, hw...)
+	} else {
+		expanded = append(expanded, hw[:3]...)
+		expanded = append(expanded, 0xff, 0xfe)
+		expanded = append(expanded, hw[3:]...)
+	}
+	return &EUI{HardwareAddr: &expanded}
+}
+
+// ToIPv6LinkLocal returns the fe80::/64 link-local IPv6 address derived from
+// the EUI, using the modified EUI-64 interface identifier described in
+// RFC 4291, i.e. with the universal/local bit inverted.
+//
+// Example usage:
+//
+//	eui, _ := netaddr.NewEUI("00:11:22:33:44:55")
+//	fmt.Println(eui.ToIPv6LinkLocal()) // Output: "fe80::211:22ff:fe33:4455"
+func (e *EUI) ToIPv6LinkLocal() *IPAddress {
+	ip := make(net.IP, IPv6len)
+	ip[0], ip[1] = 0xfe, 0x80
+	copy(ip[IPv6len-EUI64len:], *e.EUI64().HardwareAddr)
+	ip[IPv6len-EUI64len] ^= 0x02
+	return &IPAddress{
+		IP:      &ip,
+		version: IPv6,
+	}
+}
@@ -0,0 +1,79 @@
+package netaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEUI(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		eui      string
+		expected string
+		wantErr  bool
+	}{
+		{"colon separated", "00:11:22:33:44:55", "00:11:22:33:44:55", false},
+		{"hyphen separated", "00-11-22-33-44-55", "00:11:22:33:44:55", false},
+		{"dot separated", "0011.2233.4455", "00:11:22:33:44:55", false},
+		{"eui-64", "00:11:22:ff:fe:33:44:55", "00:11:22:ff:fe:33:44:55", false},
+		{"invalid", "00:11:22:33:44", "", true},
+		{"ipoib", "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eui, err := NewEUI(test.eui)
+			if (err != nil) != test.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, test.wantErr)
+				return
+			}
+			if test.wantErr {
+				return
+			}
+			assert.Equal(t, test.expected, eui.String())
+		})
+	}
+}
+
+func TestEUIOUI(t *testing.T) {
+	t.Parallel()
+
+	eui, err := NewEUI("00:11:22:33:44:55")
+	assert.NoError(t, err)
+	assert.Equal(t, "00:11:22", eui.OUI())
+}
+
+func TestEUI64(t *testing.T) {
+	t.Parallel()
+
+	eui, err := NewEUI("00:11:22:33:44:55")
+	assert.NoError(t, err)
+	assert.Equal(t, "00:11:22:ff:fe:33:44:55", eui.EUI64().String())
+	assert.Equal(t, "00:11:22:33:44:55", eui.String())
+
+	eui, err = NewEUI("02:11:22:33:44:55:66:77")
+	assert.NoError(t, err)
+	assert.Equal(t, "02:11:22:33:44:55:66:77", eui.EUI64().String())
+}
+
+func TestEUIToIPv6LinkLocal(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		eui      string
+		expected *IPAddress
+	}{
+		{"00:11:22:33:44:55", NewIP("fe80::211:22ff:fe33:4455")},
+		{"02:11:22:33:44:55", NewIP("fe80::11:22ff:fe33:4455")},
+		{"00:11:22:33:44:55:66:77", NewIP("fe80::211:2233:4455:6677")},
+	}
+
+	for _, test := range tests {
+		eui, err := NewEUI(test.eui)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, eui.ToIPv6LinkLocal())
+	}
+}
@@ -0,0 +1,55 @@
+package netaddr_test
+
+import (
+	"fmt"
+
+	"github.com/mogthesprog/go-netaddr"
+)
+
+// Example mirrors the Getting Started walkthrough in the package
+// documentation, so the snippets shown there keep compiling.
+func Example() {
+	ip, err := netaddr.NewIPAddress("192.168.1.1")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	network, err := netaddr.NewIPNetwork("192.168.1.0/24")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(network.Contains(ip))
+
+	subnets, err := network.SplitIntoSubnets(4)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, subnet := range subnets {
+		fmt.Println(subnet)
+	}
+
+	var ipRange netaddr.IPRange
+	if err := ipRange.UnmarshalText([]byte("192.168.1.1-192.168.1.254")); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(ipRange.ToIPSet().Contains(ip))
+
+	cidrs, err := ipRange.ToCIDRs()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(cidrs)
+	// Output:
+	// true
+	// 192.168.1.0/26
+	// 192.168.1.64/26
+	// 192.168.1.128/26
+	// 192.168.1.192/26
+	// true
+	// [192.168.1.1/32 192.168.1.2/31 192.168.1.4/30 192.168.1.8/29 192.168.1.16/28 192.168.1.32/27 192.168.1.64/26 192.168.1.128/26 192.168.1.192/27 192.168.1.224/28 192.168.1.240/29 192.168.1.248/30 192.168.1.252/31 192.168.1.254/32]
+}
@@ -0,0 +1,132 @@
+package netaddr
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// GlobToCIDRs converts an IPv4 glob, e.g. "192.168.1.*" or "10.0.0-127.*", to
+// the minimal list of CIDRs covering the same addresses. Each of the four
+// octets is either a number, a hyphenated range or an asterisk. At most one
+// octet may be a range, and once a range or asterisk has been used every
+// following octet must be an asterisk.
+//
+// Example usage:
+//
+//	cidrs, err := netaddr.GlobToCIDRs("192.168.1.*")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(cidrs) // Output: [192.168.1.0/24]
+func GlobToCIDRs(glob string) ([]*IPNetwork, error) {
+	octets := strings.Split(glob, ".")
+	if len(octets) != IPv4len {
+		return nil, fmt.Errorf("invalid ip glob %q: expected %d octets", glob, IPv4len)
+	}
+
+	first := make(net.IP, IPv4len)
+	last := make(net.IP, IPv4len)
+	seenRange, seenWildcard := false, false
+	for i, octet := range octets {
+		switch {
+		case octet == "*":
+			seenWildcard = true
+			first[i], last[i] = 0, 255
+		case strings.Contains(octet, "-"):
+			if seenRange || seenWildcard {
+				return nil, fmt.Errorf("invalid ip glob %q: range %q must precede any wildcard and be the only range", glob, octet)
+			}
+			seenRange = true
+			lower, upper, _ := strings.Cut(octet, "-")
+			lo, err := parseGlobOctet(glob, lower)
+			if err != nil {
+				return nil, err
+			}
+			hi, err := parseGlobOctet(glob, upper)
+			if err != nil {
+				return nil, err
+			}
+			if lo > hi {
+				return nil, fmt.Errorf("invalid ip glob %q: range %q is reversed", glob, octet)
+			}
+			first[i], last[i] = lo, hi
+		default:
+			if seenRange || seenWildcard {
+				return nil, fmt.Errorf("invalid ip glob %q: octet %q follows a range or wildcard", glob, octet)
+			}
+			value, err := parseGlobOctet(glob, octet)
+			if err != nil {
+				return nil, err
+			}
+			first[i], last[i] = value, value
+		}
+	}
+
+	return IPRangeToCIDRS(IPv4,
+		&IPAddress{IP: &first, version: IPv4},
+		&IPAddress{IP: &last, version: IPv4})
+}
+
+// CIDRToGlob converts an IPv4 network to its equivalent glob. Octets covered
+// entirely by the host bits are rendered as asterisks, and an octet only
+// partially covered is rendered as a hyphenated range, e.g. "192.168.1.0-127"
+// for 192.168.1.0/25. An error is returned for IPv6 networks.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	glob, err := netaddr.CIDRToGlob(nw)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(glob) // Output: "192.168.1.*"
+func CIDRToGlob(nw *IPNetwork) (string, error) {
+	if nw.version != IPv4 {
+		return "", fmt.Errorf("cannot convert %s to a glob: globs are only supported for IPv4", nw)
+	}
+
+	first, last := *nw.First().IP, *nw.Last().IP
+	octets := make([]string, IPv4len)
+	for i := range octets {
+		switch {
+		case first[i] == last[i]:
+			octets[i] = strconv.Itoa(int(first[i]))
+		case first[i] == 0 && last[i] == 255:
+			octets[i] = "*"
+		default:
+			octets[i] = fmt.Sprintf("%d-%d", first[i], last[i])
+		}
+	}
+	return strings.Join(octets, "."), nil
+}
+
+// Glob renders the network as a wildcard string, e.g. "192.168.1.*" for
+// 192.168.1.0/24. Unlike CIDRToGlob, only networks whose prefix length falls
+// on an octet boundary can be rendered, and an error is returned otherwise or
+// for IPv6 networks.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.0.0/16")
+//	glob, err := nw.Glob()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(glob) // Output: "192.168.*.*"
+func (nw *IPNetwork) Glob() (string, error) {
+	if ones, _ := nw.Mask.Size(); ones%8 != 0 {
+		return "", fmt.Errorf("cannot convert %s to a wildcard: prefix length is not a multiple of 8", nw)
+	}
+	return CIDRToGlob(nw)
+}
+
+// parseGlobOctet parses a single numeric octet of glob.
+func parseGlobOctet(glob, octet string) (byte, error) {
+	value, err := strconv.ParseUint(octet, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ip glob %q: octet %q is not a number between 0 and 255", glob, octet)
+	}
+	return byte(value), nil
+}
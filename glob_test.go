@@ -0,0 +1,105 @@
+package netaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobToCIDRs(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		glob     string
+		expected []*IPNetwork
+		wantErr  bool
+	}{
+		{"192.168.1.*", []*IPNetwork{newTestNetwork(t, "192.168.1.0/24")}, false},
+		{"10.*.*.*", []*IPNetwork{newTestNetwork(t, "10.0.0.0/8")}, false},
+		{"*.*.*.*", []*IPNetwork{newTestNetwork(t, "0.0.0.0/0")}, false},
+		{"10.0.0-127.*", []*IPNetwork{newTestNetwork(t, "10.0.0.0/17")}, false},
+		{"192.168.1.1", []*IPNetwork{newTestNetwork(t, "192.168.1.1/32")}, false},
+		{"192.168.1.1-6", []*IPNetwork{
+			newTestNetwork(t, "192.168.1.1/32"), newTestNetwork(t, "192.168.1.2/31"),
+			newTestNetwork(t, "192.168.1.4/31"), newTestNetwork(t, "192.168.1.6/32"),
+		}, false},
+		{"10.0.0.5-5", []*IPNetwork{newTestNetwork(t, "10.0.0.5/32")}, false},
+		{"10.0.3-3.*", []*IPNetwork{newTestNetwork(t, "10.0.3.0/24")}, false},
+		{"192.168.1", nil, true},
+		{"192.168.*.1", nil, true},
+		{"192.168.1.256", nil, true},
+		{"192.168.1.a", nil, true},
+		{"10.*.1-2.*", nil, true},
+		{"10.1-2.3-4.*", nil, true},
+		{"10.0.5-1.*", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.glob, func(t *testing.T) {
+			result, err := GlobToCIDRs(test.glob)
+			if (err != nil) != test.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, test.wantErr)
+				return
+			}
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestCIDRToGlob(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		nw       *IPNetwork
+		expected string
+		wantErr  bool
+	}{
+		{newTestNetwork(t, "192.168.1.0/24"), "192.168.1.*", false},
+		{newTestNetwork(t, "10.0.0.0/8"), "10.*.*.*", false},
+		{newTestNetwork(t, "0.0.0.0/0"), "*.*.*.*", false},
+		{newTestNetwork(t, "10.0.0.0/17"), "10.0.0-127.*", false},
+		{newTestNetwork(t, "192.168.1.128/25"), "192.168.1.128-255", false},
+		{newTestNetwork(t, "192.168.1.1/32"), "192.168.1.1", false},
+		{newTestNetwork(t, "2001:db8::/32"), "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.nw.String(), func(t *testing.T) {
+			result, err := CIDRToGlob(test.nw)
+			if (err != nil) != test.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, test.wantErr)
+				return
+			}
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestIPNetworkGlob(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		cidr     string
+		expected string
+		wantErr  bool
+	}{
+		{"192.168.1.0/24", "192.168.1.*", false},
+		{"192.168.0.0/16", "192.168.*.*", false},
+		{"0.0.0.0/0", "*.*.*.*", false},
+		{"192.168.1.1/32", "192.168.1.1", false},
+		{"192.168.1.0/25", "", true},
+		{"2001:db8::/32", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.cidr, func(t *testing.T) {
+			glob, err := newTestNetwork(t, test.cidr).Glob()
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, glob)
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package netaddr
 
 import (
+	"database/sql/driver"
+	"encoding/binary"
 	"fmt"
+	"math"
 	"math/big"
+	"math/bits"
 	"net"
+	"net/netip"
+	"strings"
 )
 
 const (
@@ -15,6 +21,15 @@ const (
 var (
 	// ErrorAddressOutOFBounds is an error returned when an IP number exceeds the IP version boundary.
 	ErrorAddressOutOFBounds = fmt.Errorf("ip number out range of ip-version boundary")
+
+	// ErrorInvalidAddress is an error returned when an operation is attempted
+	// on an IPAddress that does not hold a valid address, e.g. one returned by
+	// NewIP for unparseable input.
+	ErrorInvalidAddress = fmt.Errorf("invalid ip address")
+
+	// ErrorBitOutOfRange is an error returned when a bit index is outside the
+	// bit length of the address version.
+	ErrorBitOutOfRange = fmt.Errorf("bit index out of range of ip-version bit length")
 )
 
 var (
@@ -39,14 +54,51 @@ var (
 	}
 )
 
+var (
+	// Well-known address blocks used to classify IP addresses.
+	privateNetworks = []*IPNetwork{
+		mustNewIPNetwork("10.0.0.0/8"),
+		mustNewIPNetwork("172.16.0.0/12"),
+		mustNewIPNetwork("192.168.0.0/16"),
+		mustNewIPNetwork("fc00::/7"),
+	}
+	loopbackNetworks = []*IPNetwork{
+		mustNewIPNetwork("127.0.0.0/8"),
+		mustNewIPNetwork("::1/128"),
+	}
+	multicastNetworks = []*IPNetwork{
+		mustNewIPNetwork("224.0.0.0/4"),
+		mustNewIPNetwork("ff00::/8"),
+	}
+	linkLocalNetworks = []*IPNetwork{
+		mustNewIPNetwork("169.254.0.0/16"),
+		mustNewIPNetwork("fe80::/10"),
+	}
+
+	// IPv6 transition mechanism prefixes embedding IPv4 addresses.
+	sixToFourNetwork = mustNewIPNetwork("2002::/16")
+	teredoNetwork    = mustNewIPNetwork("2001::/32")
+)
+
 type (
-	// IPNumber is the integer representation of an IP address.
-	IPNumber struct{ *big.Int }
+	// IPNumber is the integer representation of an IP address. It optionally
+	// carries the version of the address it represents, so that it can be
+	// converted back to an address of the correct width.
+	//
+	// Arithmetic and comparisons on IPv4 numbers read the value from the
+	// big.Int as a uint32 and compute it without big.Int arithmetic.
+	IPNumber struct {
+		*big.Int
+		version *Version
+	}
 
-	// IPAddress represents an IP address and its version (IPv4 or IPv6).
+	// IPAddress represents an IP address and its version (IPv4 or IPv6). IPv6
+	// addresses may carry a zone, e.g. "eth0" in "fe80::1%eth0", which is
+	// ignored by arithmetic and comparisons.
 	IPAddress struct {
 		*net.IP
 		version *Version
+		zone    string
 	}
 
 	// Version represents the IP version (IPv4 or IPv6) and its properties.
@@ -77,26 +129,157 @@ func NewMask(ones, bits int64) *IPMask {
 }
 
 // NewIP returns a new IPAddress object, initialized with the IP info parsed from ip.
+// IPv6 addresses may carry a zone after a "%", e.g. "fe80::1%eth0".
+// If ip cannot be parsed the returned IPAddress holds no address and has no
+// version; use NewIPAddress to get an error instead.
 //
 // Example usage:
 //
 //	ip := netaddr.NewIP("192.168.1.1")
 //	fmt.Println(ip)
 func NewIP(ip string) *IPAddress {
-	newIP := net.ParseIP(ip)
-	if newIP.To4() != nil {
-		newIP = newIP.To4()
-		return &IPAddress{
-			IP:      &newIP,
-			version: IPv4,
+	host, zone, hasZone := strings.Cut(ip, "%")
+	newIP := net.ParseIP(host)
+	if newIP == nil || (hasZone && (zone == "" || newIP.To4() != nil)) {
+		return &IPAddress{IP: &net.IP{}}
+	}
+	addr := &IPAddress{IP: &newIP, zone: zone}
+	addr.Canonicalize()
+	return addr
+}
+
+// Canonicalize normalizes the address in place so that it is stored in the
+// canonical form of its version: 4 bytes for IPv4 and 16 bytes for IPv6. If
+// the address has no version yet, as for an IPAddress built directly from a
+// net.IP, the version is inferred like NewIP does, so IPv4-mapped IPv6
+// addresses become IPv4. Every constructor of this package returns canonical
+// addresses, so equal addresses are also reflect.DeepEqual. Addresses holding
+// no valid address are left unchanged.
+//
+// Example usage:
+//
+//	raw := net.ParseIP("192.168.1.1") // 16 bytes
+//	ip := &netaddr.IPAddress{IP: &raw}
+//	ip.Canonicalize()
+//	fmt.Println(len(*ip.IP), ip.Version()) // Output: 4 IPv4
+func (ip *IPAddress) Canonicalize() {
+	if ip.IP == nil || (len(*ip.IP) != IPv4len && len(*ip.IP) != IPv6len) {
+		return
+	}
+	version := ip.version
+	if version == nil {
+		version = IPv6
+		if ip.IP.To4() != nil {
+			version = IPv4
 		}
 	}
 
-	newIP = newIP.To16()
-	return &IPAddress{
-		IP:      &newIP,
-		version: IPv6,
+	canonical := ip.IP.To16()
+	if version == IPv4 {
+		if canonical = ip.IP.To4(); canonical == nil {
+			return
+		}
+		ip.zone = ""
+	}
+	ip.IP = &canonical
+	ip.version = version
+}
+
+// NewIPAddress returns a new IPAddress parsed from s, like NewIP, but returns
+// an error instead of an unusable address if s is not a valid IPv4 or IPv6
+// address.
+//
+// Example usage:
+//
+//	ip, err := netaddr.NewIPAddress("192.168.1.1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(ip) // Output: "192.168.1.1"
+func NewIPAddress(s string) (*IPAddress, error) {
+	ip := NewIP(s)
+	if ip.Version() == nil {
+		return nil, fmt.Errorf("invalid ip address: %q", s)
+	}
+	return ip, nil
+}
+
+// NewIPFromInt returns the IPAddress of the passed version whose integer
+// value is n. ErrorAddressOutOFBounds is returned if n is negative or exceeds
+// the largest address of the version.
+//
+// Example usage:
+//
+//	ip, err := netaddr.NewIPFromInt(netaddr.NewIPNumber(3232235777), netaddr.IPv4)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(ip) // Output: "192.168.1.1"
+func NewIPFromInt(n *IPNumber, version *Version) (*IPAddress, error) {
+	if version != IPv4 && version != IPv6 {
+		return nil, fmt.Errorf("invalid ip version: %v", version)
+	}
+	if n.Sign() < 0 || n.Cmp(version.max.Int) > 0 {
+		return nil, ErrorAddressOutOFBounds
+	}
+	return n.ToIPAddressV(version), nil
+}
+
+// ParseIntAddress parses s as the decimal integer value of an address of the
+// passed version, e.g. "3232235777" for the IPv4 address 192.168.1.1. An
+// error is returned if s is not a decimal integer or is out of range for the
+// version, see NewIPFromInt.
+//
+// Example usage:
+//
+//	ip, err := netaddr.ParseIntAddress("3232235777", netaddr.IPv4)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(ip) // Output: "192.168.1.1"
+func ParseIntAddress(s string, version *Version) (*IPAddress, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer address: %q", s)
+	}
+	return NewIPFromInt(&IPNumber{Int: n}, version)
+}
+
+// FromNetipAddr returns a new IPAddress for the passed netip.Addr. IPv4-mapped
+// IPv6 addresses are unmapped, so the result is IPv4 just as with NewIP. The
+// zero netip.Addr is not a valid address and yields nil.
+//
+// Example usage:
+//
+//	ip := netaddr.FromNetipAddr(netip.MustParseAddr("192.168.1.1"))
+//	fmt.Println(ip) // Output: "192.168.1.1"
+func FromNetipAddr(a netip.Addr) *IPAddress {
+	if !a.IsValid() {
+		return nil
 	}
+	a = a.Unmap()
+	ip := net.IP(a.AsSlice())
+	addr := &IPAddress{IP: &ip, zone: a.Zone()}
+	addr.Canonicalize()
+	return addr
+}
+
+// ToNetipAddr returns the netip.Addr equivalent of the IPAddress, including
+// its zone. An IPAddress holding no address yields the zero netip.Addr.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.ToNetipAddr()) // Output: "192.168.1.1"
+func (ip *IPAddress) ToNetipAddr() netip.Addr {
+	if ip == nil || ip.IP == nil {
+		return netip.Addr{}
+	}
+	addr, _ := netip.AddrFromSlice(*ip.IP)
+	if ip.Version() == IPv4 {
+		return addr.Unmap()
+	}
+	return addr.WithZone(ip.zone)
 }
 
 // NewIPNumber returns an IPNumber for the passed number.
@@ -126,14 +309,217 @@ func (v *Version) String() string {
 	}
 }
 
-// String returns the string representation of address ip.
+// Number returns the number of IP version v, i.e. 4 or 6.
+//
+// Example usage:
+//
+//	fmt.Println(netaddr.IPv4.Number()) // Output: 4
+func (v *Version) Number() int {
+	return int(v.number)
+}
+
+// BitLength returns the number of bits in an address of IP version v.
+//
+// Example usage:
+//
+//	fmt.Println(netaddr.IPv6.BitLength()) // Output: 128
+func (v *Version) BitLength() int {
+	return int(v.bitLength)
+}
+
+// Max returns the largest address, as an IPNumber of version v, of IP version
+// v. The returned value is a copy and may be modified freely.
+//
+// Example usage:
+//
+//	fmt.Println(netaddr.IPv4.Max()) // Output: 4294967295
+func (v *Version) Max() *IPNumber {
+	return newBigIPNumber(big.NewInt(0).Set(v.max.Int), v)
+}
+
+// String returns the string representation of address ip, followed by "%"
+// and its zone if it has one. IPv4-mapped addresses of version IPv6 keep the
+// "::ffff:" prefix, e.g. "::ffff:192.168.1.1", so they parse back as IPv6.
 //
 // Example usage:
 //
 //	ip := netaddr.NewIP("192.168.1.1")
 //	fmt.Println(ip.String()) // Output: "192.168.1.1"
 func (ip *IPAddress) String() string {
-	return ip.IP.String()
+	if ip == nil || ip.IP == nil {
+		return "<nil>"
+	}
+	if ip.version == IPv6 && len(*ip.IP) == IPv6len && ip.IP.To4() != nil {
+		return "::ffff:" + ip.IP.To4().String() + ip.zoneSuffix()
+	}
+	return ip.IP.String() + ip.zoneSuffix()
+}
+
+// Zone returns the zone of the address, e.g. "eth0" for "fe80::1%eth0", or
+// an empty string if it has none.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("fe80::1%eth0")
+//	fmt.Println(ip.Zone()) // Output: "eth0"
+func (ip *IPAddress) Zone() string {
+	return ip.zone
+}
+
+// WithZone returns a copy of the address with its zone set to z. An empty z
+// removes the zone. Only IPv6 addresses can carry a zone, so any other
+// address is returned without one.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("fe80::1").WithZone("eth0")
+//	fmt.Println(ip) // Output: "fe80::1%eth0"
+func (ip *IPAddress) WithZone(z string) *IPAddress {
+	zoned := *ip
+	zoned.zone = ""
+	if ip.Version() == IPv6 {
+		zoned.zone = z
+	}
+	return &zoned
+}
+
+// zoneSuffix returns the zone of the address prefixed by "%", or an empty
+// string if it has none.
+func (ip *IPAddress) zoneSuffix() string {
+	if ip.zone == "" {
+		return ""
+	}
+	return "%" + ip.zone
+}
+
+// StringCompressed returns the compressed string representation of address
+// ip, e.g. "2001:db8::1". It is the same as String.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("2001:0db8:0000:0000:0000:0000:0000:0001")
+//	fmt.Println(ip.StringCompressed()) // Output: "2001:db8::1"
+func (ip *IPAddress) StringCompressed() string {
+	return ip.String()
+}
+
+// StringExpanded returns the fully expanded string representation of address
+// ip, with all eight hextets of an IPv6 address zero padded to four digits,
+// e.g. "2001:0db8:0000:0000:0000:0000:0000:0001". IPv4 addresses have no
+// expanded form and are returned as by String.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("2001:db8::1")
+//	fmt.Println(ip.StringExpanded()) // Output: "2001:0db8:0000:0000:0000:0000:0000:0001"
+func (ip *IPAddress) StringExpanded() string {
+	if ip.Version() != IPv6 {
+		return ip.String()
+	}
+	hextets := make([]string, 0, IPv6len/2)
+	for _, word := range ip.Words() {
+		hextets = append(hextets, fmt.Sprintf("%04x", word))
+	}
+	return strings.Join(hextets, ":") + ip.zoneSuffix()
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the canonical
+// string representation of the address.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	data, _ := json.Marshal(ip)
+//	fmt.Println(string(data)) // Output: "\"192.168.1.1\""
+func (ip *IPAddress) MarshalText() ([]byte, error) {
+	return []byte(ip.String()), nil
+}
+
+// AppendText implements encoding.TextAppender, appending the same text as
+// MarshalText to b. It shadows the method promoted from the embedded net.IP,
+// which would drop the zone.
+func (ip *IPAddress) AppendText(b []byte) ([]byte, error) {
+	return append(b, ip.String()...), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the address in
+// the same way as NewIP. An error is returned if text is not a valid address.
+//
+// Example usage:
+//
+//	var ip netaddr.IPAddress
+//	if err := json.Unmarshal([]byte(`"192.168.1.1"`), &ip); err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(ip.String()) // Output: "192.168.1.1"
+func (ip *IPAddress) UnmarshalText(text []byte) error {
+	parsed, err := NewIPAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*ip = *parsed
+	return nil
+}
+
+// Scan implements sql.Scanner, parsing the address from a string or []byte
+// database value. Scanning NULL resets ip to the zero IPAddress.
+//
+// Example usage:
+//
+//	var ip netaddr.IPAddress
+//	err := db.QueryRow("SELECT addr FROM hosts").Scan(&ip)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+func (ip *IPAddress) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*ip = IPAddress{}
+		return nil
+	case string:
+		return ip.UnmarshalText([]byte(v))
+	case []byte:
+		return ip.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into IPAddress", src)
+	}
+}
+
+// Value implements driver.Valuer, returning the canonical string
+// representation of the address. An IPAddress holding no address, such as
+// one scanned from NULL, is written as NULL.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	_, err := db.Exec("INSERT INTO hosts (addr) VALUES ($1)", ip)
+func (ip *IPAddress) Value() (driver.Value, error) {
+	if ip == nil || ip.Version() == nil {
+		return nil, nil
+	}
+	return ip.String(), nil
+}
+
+// FirstInt returns the integer value of the address. It implements
+// Addressable, for which an address is a block of one.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.FirstInt()) // Output: 3232235777
+func (ip *IPAddress) FirstInt() *IPNumber {
+	return ip.ToInt()
+}
+
+// LastInt returns the integer value of the address, like FirstInt. It
+// implements Addressable.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.LastInt()) // Output: 3232235777
+func (ip *IPAddress) LastInt() *IPNumber {
+	return ip.ToInt()
 }
 
 // Version returns the IP version for IPAddress, ip.
@@ -143,6 +529,9 @@ func (ip *IPAddress) String() string {
 //	ip := netaddr.NewIP("192.168.1.1")
 //	fmt.Println(ip.Version().String()) // Output: "IPv4"
 func (ip *IPAddress) Version() *Version {
+	if ip.IP == nil {
+		return nil
+	}
 	if len(*ip.IP) == IPv6len {
 		return IPv6
 	}
@@ -152,30 +541,227 @@ func (ip *IPAddress) Version() *Version {
 	return nil
 }
 
-// Increment increments the IPAddress by an amount, val, which is of big.Int type.
+// Increment returns a new IPAddress incremented by an amount, val, which is
+// of big.Int type. The receiver is left unchanged; the behaviour matches Add.
 //
 // Example usage:
 //
 //	ip := netaddr.NewIP("192.168.1.1")
 //	val := netaddr.NewIPNumber(1)
-//	ip, err := ip.Increment(val)
+//	next, err := ip.Increment(val)
 //	if err != nil {
 //	    fmt.Println(err)
 //	}
-//	fmt.Println(ip) // Output: "192.168.1.2"
+//	fmt.Println(next) // Output: "192.168.1.2"
 func (ip *IPAddress) Increment(val *IPNumber) (*IPAddress, error) {
-	ipNum := ip.ToInt()
-	if ipNum.Equal(NewIPNumber(0)) {
-		return ip, nil
+	return ip.Add(val)
+}
+
+// Add returns a new IPAddress n addresses after ip, of the same version and
+// with the same zone. ErrorAddressOutOFBounds is returned if the result would
+// fall outside the address space of the version, rather than wrapping around.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	next, err := ip.Add(netaddr.NewIPNumber(1))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(next) // Output: "192.168.1.2"
+func (ip *IPAddress) Add(n *IPNumber) (*IPAddress, error) {
+	version := ip.Version()
+	if version == nil {
+		return nil, ErrorInvalidAddress
+	}
+	ipNum := ip.ToInt().Add(n)
+	if ipNum.LessThan(NewIPNumber(0)) || ipNum.GreaterThan(version.max) {
+		return nil, ErrorAddressOutOFBounds
+	}
+	next := ipNum.ToIPAddressV(version)
+	next.zone = ip.zone
+	return next, nil
+}
+
+// Sub returns a new IPAddress n addresses before ip, of the same version.
+// ErrorAddressOutOFBounds is returned if the result would fall outside the
+// address space of the version, rather than wrapping around.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	previous, err := ip.Sub(netaddr.NewIPNumber(1))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(previous) // Output: "192.168.1.0"
+func (ip *IPAddress) Sub(n *IPNumber) (*IPAddress, error) {
+	return ip.Add(n.Neg())
+}
+
+// Mask returns the address with the host bits not covered by mask m cleared,
+// i.e. the network address of ip within a network with that mask. The result
+// has the version and zone of ip. nil is returned if the mask is not of the
+// same length as the address.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.130")
+//	fmt.Println(ip.Mask(netaddr.NewMask(24, 32))) // Output: "192.168.1.0"
+func (ip *IPAddress) Mask(m *IPMask) *IPAddress {
+	version := ip.Version()
+	if version == nil || int64(len(*m.IPMask)) != version.length {
+		return nil
+	}
+	masked := ip.IP.Mask(*m.IPMask)
+	return &IPAddress{
+		IP:      &masked,
+		version: version,
+		zone:    ip.zone,
+	}
+}
+
+// Bits returns the binary representation of the address, zero padded to the
+// bit length of its version. An empty string is returned for an IPAddress
+// holding no address.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.Bits()) // Output: "11000000101010000000000100000001"
+func (ip *IPAddress) Bits() string {
+	if ip.Version() == nil {
+		return ""
+	}
+	return fmt.Sprintf("%0*b", ip.Version().bitLength, ip.ToInt().Int)
+}
+
+// BinaryGrouped returns the binary representation of the address split into
+// its words: 8-bit octets separated by "." for IPv4 and 16-bit hextets
+// separated by ":" for IPv6. An empty string is returned for an IPAddress
+// holding no address.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.BinaryGrouped()) // Output: "11000000.10101000.00000001.00000001"
+func (ip *IPAddress) BinaryGrouped() string {
+	bits := ip.Bits()
+	if bits == "" {
+		return ""
+	}
+
+	width, sep := 16, ":"
+	if ip.Version() == IPv4 {
+		width, sep = 8, "."
+	}
+	groups := make([]string, 0, len(bits)/width)
+	for i := 0; i < len(bits); i += width {
+		groups = append(groups, bits[i:i+width])
+	}
+	return strings.Join(groups, sep)
+}
+
+// BitAt returns bit i of the address, 0 or 1, counting from the most
+// significant bit within the bit length of its version, so bit 0 of an IPv4
+// address is the top bit of its first octet. ErrorBitOutOfRange is returned if
+// i is out of range for the version, and ErrorInvalidAddress if ip holds no
+// address.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("128.0.0.0")
+//	bit, err := ip.BitAt(0)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(bit) // Output: 1
+func (ip *IPAddress) BitAt(i int) (int, error) {
+	if err := ip.checkBit(i); err != nil {
+		return 0, err
+	}
+	return int(addressBit(*ip.IP, i)), nil
+}
+
+// WithBit returns a copy of the address with bit i, counted as by BitAt, set
+// to 1 if v is non-zero and cleared otherwise. The receiver is left
+// unchanged. Errors are returned as by BitAt.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("0.0.0.1")
+//	next, err := ip.WithBit(0, 1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(next) // Output: "128.0.0.1"
+func (ip *IPAddress) WithBit(i int, v int) (*IPAddress, error) {
+	if err := ip.checkBit(i); err != nil {
+		return nil, err
 	}
-	ipNum = ipNum.Add(val)
-	if ipNum.GreaterThanOrEqual(NewIPNumber(0)) &&
-		ipNum.LessThanOrEqual(ip.Version().max) {
-		ip.IP = ipNum.ToIPAddress().IP
-		return ip, nil
+	bytes := append(net.IP{}, *ip.IP...)
+	mask := byte(1) << (7 - i%8)
+	if v != 0 {
+		bytes[i/8] |= mask
+	} else {
+		bytes[i/8] &^= mask
+	}
+	return &IPAddress{
+		IP:      &bytes,
+		version: ip.version,
+		zone:    ip.zone,
+	}, nil
+}
+
+// checkBit returns an error unless i is a valid bit index for ip, see BitAt.
+func (ip *IPAddress) checkBit(i int) error {
+	version := ip.Version()
+	if version == nil {
+		return ErrorInvalidAddress
+	}
+	if i < 0 || i >= version.BitLength() {
+		return ErrorBitOutOfRange
+	}
+	return nil
+}
+
+// Hex returns the hexadecimal representation of the address without
+// separators, zero padded to the bit length of its version. An empty string
+// is returned for an IPAddress holding no address.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.Hex()) // Output: "c0a80101"
+func (ip *IPAddress) Hex() string {
+	if ip.Version() == nil {
+		return ""
+	}
+	return fmt.Sprintf("%0*x", ip.Version().bitLength/4, ip.ToInt().Int)
+}
+
+// Words returns the numeric components of the address: the four octets of an
+// IPv4 address or the eight 16-bit hextets of an IPv6 address.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.Words()) // Output: [192 168 1 1]
+func (ip *IPAddress) Words() []int {
+	bytes := *ip.IP
+	if ip.Version() == IPv4 {
+		words := make([]int, len(bytes))
+		for i, b := range bytes {
+			words[i] = int(b)
+		}
+		return words
 	}
 
-	return nil, ErrorAddressOutOFBounds
+	words := make([]int, len(bytes)/2)
+	for i := range words {
+		words[i] = int(binary.BigEndian.Uint16(bytes[2*i:]))
+	}
+	return words
 }
 
 // ValidIPV4 returns true when the passed bytes are a valid IPV4.
@@ -185,8 +771,9 @@ func (ip *IPAddress) Increment(val *IPNumber) (*IPAddress, error) {
 //	ip := net.ParseIP("192.168.1.1")
 //	fmt.Println(netaddr.ValidIPV4(ip)) // Output: true
 func ValidIPV4(ipBytes []byte) bool {
-	// we need to check for length 0, since big.Int has length 0 for big.Int(0)
-	if len(ipBytes) == IPv4len || len(ipBytes) == 0 {
+	// we need to check for lengths below IPv4len, since big.Int drops leading
+	// zero bytes (e.g. 0.0.0.1 is a single byte, and big.Int(0) has length 0)
+	if len(ipBytes) <= IPv4len {
 		return true
 	} else if len(ipBytes) != IPv6len {
 		return false
@@ -196,7 +783,7 @@ func ValidIPV4(ipBytes []byte) bool {
 	// Check if the first 12 bytes are all zero (i.e. IPv4)
 	for i, v := range ipBytes {
 		// 11 is the last index of ipv6 ONLY address bytes
-		if v == 0 && i <= IPv6len-IPv4len-1 {
+		if v != 0 && i <= IPv6len-IPv4len-1 {
 			ipv4Flag = false
 		}
 	}
@@ -205,6 +792,9 @@ func ValidIPV4(ipBytes []byte) bool {
 }
 
 // ToInt returns the integer representation (IPNumber) for the given IPAddress.
+// The IPNumber carries the version of ip, so ToIPAddress reconstructs an
+// address of the same version. An IPAddress holding no address converts to
+// zero without a version.
 //
 // Example usage:
 //
@@ -212,12 +802,21 @@ func ValidIPV4(ipBytes []byte) bool {
 //	ipNum := ip.ToInt()
 //	fmt.Println(ipNum)
 func (ip *IPAddress) ToInt() *IPNumber {
-	num := NewIPNumber(0)
-	num.SetBytes(*ip.IP)
-	return num
+	version := ip.Version()
+	if version == nil {
+		return NewIPNumber(0)
+	}
+	if version == IPv4 {
+		return newIPv4Number(binary.BigEndian.Uint32(ip.To4()))
+	}
+	return newBigIPNumber(new(big.Int).SetBytes(*ip.IP), version)
 }
 
-// ToIPAddress converts the given IPNumber object to an IPAddress.
+// ToIPAddress converts the given IPNumber object to an IPAddress. If num
+// carries a version, e.g. because it was returned by IPAddress.ToInt, an
+// address of that version is returned. Otherwise the version is inferred from
+// the magnitude of the number, so numbers that fit within 32 bits are
+// converted to IPv4; use ToIPAddressV to choose the version explicitly.
 //
 // Example usage:
 //
@@ -225,24 +824,34 @@ func (ip *IPAddress) ToInt() *IPNumber {
 //	ip := ipNum.ToIPAddress()
 //	fmt.Println(ip.String()) // Output: "192.168.1.1"
 func (num *IPNumber) ToIPAddress() *IPAddress {
-	var (
-		bytes   net.IP
-		version *Version
-	)
-	// get the bytes of bigInt
-	bigintBytes := num.Bytes()
+	if num.version != nil {
+		return num.ToIPAddressV(num.version)
+	}
+	if ValidIPV4(num.Bytes()) {
+		return num.ToIPAddressV(IPv4)
+	}
+	return num.ToIPAddressV(IPv6)
+}
 
-	if ValidIPV4(bigintBytes) {
-		bytes = make(net.IP, 4)
-		version = IPv4
-	} else {
-		bytes = make(net.IP, 16)
-		version = IPv6
+// ToIPAddressV converts the given IPNumber object to an IPAddress of the
+// passed version.
+//
+// Example usage:
+//
+//	ipNum := netaddr.NewIPNumber(1)
+//	ip := ipNum.ToIPAddressV(netaddr.IPv6)
+//	fmt.Println(ip.String()) // Output: "::1"
+func (num *IPNumber) ToIPAddressV(version *Version) *IPAddress {
+	if v, ok := num.ipv4Value(); ok && version == IPv4 {
+		return newIPv4Address(v)
 	}
+	bytes := make(net.IP, version.length)
+	// get the bytes of bigInt
+	bigintBytes := num.Bytes()
 
 	for i := 0; i < len(bytes); i++ {
-		// Handle the case where len(bigintbytes) == 0. This is the case for a
-		// zero big.Int type.
+		// Handle the case where bigintBytes is shorter than the address, e.g.
+		// a zero big.Int type has no bytes at all.
 		if len(bigintBytes) == i {
 			break
 		}
@@ -262,8 +871,7 @@ func (num *IPNumber) ToIPAddress() *IPAddress {
 //	ipNum2 := netaddr.NewIPNumber(3232235778) // 192.168.1.2
 //	fmt.Println(ipNum1.GreaterThan(ipNum2)) // Output: false
 func (num *IPNumber) GreaterThan(other *IPNumber) bool {
-	cmp := num.Cmp(other.Int)
-	return cmp == 1
+	return num.cmp(other) == 1
 }
 
 // GreaterThanOrEqual compares two IPNumbers, returning true when num is greater than or equal to other.
@@ -274,10 +882,7 @@ func (num *IPNumber) GreaterThan(other *IPNumber) bool {
 //	ipNum2 := netaddr.NewIPNumber(3232235778) // 192.168.1.2
 //	fmt.Println(ipNum1.GreaterThanOrEqual(ipNum2)) // Output: false
 func (num *IPNumber) GreaterThanOrEqual(other *IPNumber) bool {
-	if cmp := num.Cmp(other.Int); cmp >= 0 {
-		return true
-	}
-	return false
+	return num.cmp(other) >= 0
 }
 
 // LessThan compares two IPNumbers, returning true when num is less than other.
@@ -288,8 +893,7 @@ func (num *IPNumber) GreaterThanOrEqual(other *IPNumber) bool {
 //	ipNum2 := netaddr.NewIPNumber(3232235778) // 192.168.1.2
 //	fmt.Println(ipNum1.LessThan(ipNum2)) // Output: true
 func (num *IPNumber) LessThan(other *IPNumber) bool {
-	cmp := num.Cmp(other.Int)
-	return cmp == -1
+	return num.cmp(other) == -1
 }
 
 // LessThanOrEqual compares two IPNumbers, returning true when num is less than or equal to other.
@@ -300,10 +904,7 @@ func (num *IPNumber) LessThan(other *IPNumber) bool {
 //	ipNum2 := netaddr.NewIPNumber(3232235778) // 192.168.1.2
 //	fmt.Println(ipNum1.LessThanOrEqual(ipNum2)) // Output: true
 func (num *IPNumber) LessThanOrEqual(other *IPNumber) bool {
-	if cmp := num.Cmp(other.Int); cmp <= 0 {
-		return true
-	}
-	return false
+	return num.cmp(other) <= 0
 }
 
 // Equal compares two IPNumbers, returning true when num is equal to other.
@@ -314,8 +915,22 @@ func (num *IPNumber) LessThanOrEqual(other *IPNumber) bool {
 //	ipNum2 := netaddr.NewIPNumber(3232235777) // 192.168.1.1
 //	fmt.Println(ipNum1.Equal(ipNum2)) // Output: true
 func (num *IPNumber) Equal(other *IPNumber) bool {
-	cmp := num.Cmp(other.Int)
-	return cmp == 0
+	return num.cmp(other) == 0
+}
+
+// cmp compares num and other, returning -1, 0 or +1 like big.Int.Cmp. IPv4
+// numbers are compared as uint64 values without going through big.Int.Cmp.
+func (num *IPNumber) cmp(other *IPNumber) int {
+	if a, b, ok := num.ipv4Operands(other); ok {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	return num.Cmp(other.Int)
 }
 
 // Add adds two IPNumbers and returns the result.
@@ -327,8 +942,13 @@ func (num *IPNumber) Equal(other *IPNumber) bool {
 //	result := ipNum1.Add(ipNum2)
 //	fmt.Println(result) // Output: 3232235778
 func (num *IPNumber) Add(v *IPNumber) *IPNumber {
+	if a, b, ok := num.ipv4Operands(v); ok {
+		if sum, carry := bits.Add64(a, b, 0); carry == 0 {
+			return newIPv4Result(sum)
+		}
+	}
 	int := big.NewInt(0).Add(num.Int, v.Int)
-	return &IPNumber{int}
+	return newBigIPNumber(int, num.version)
 }
 
 // Sub subtracts v from num and returns the result.
@@ -340,8 +960,11 @@ func (num *IPNumber) Add(v *IPNumber) *IPNumber {
 //	result := ipNum1.Sub(ipNum2)
 //	fmt.Println(result) // Output: 3232235777
 func (num *IPNumber) Sub(v *IPNumber) *IPNumber {
+	if a, b, ok := num.ipv4Operands(v); ok && a >= b {
+		return newIPv4Result(a - b)
+	}
 	int := big.NewInt(0).Sub(num.Int, v.Int)
-	return &IPNumber{int}
+	return newBigIPNumber(int, num.version)
 }
 
 // Exp raises num to the power of v and returns the result.
@@ -354,7 +977,7 @@ func (num *IPNumber) Sub(v *IPNumber) *IPNumber {
 //	fmt.Println(result) // Output: 256
 func (num *IPNumber) Exp(v *IPNumber) *IPNumber {
 	int := big.NewInt(0).Exp(num.Int, v.Int, nil)
-	return &IPNumber{int}
+	return newBigIPNumber(int, num.version)
 }
 
 // And performs a bitwise AND operation on num and v, returning the result.
@@ -366,8 +989,11 @@ func (num *IPNumber) Exp(v *IPNumber) *IPNumber {
 //	result := ipNum1.And(ipNum2)
 //	fmt.Println(result) // Output: 1
 func (num *IPNumber) And(v *IPNumber) *IPNumber {
+	if a, b, ok := num.ipv4Operands(v); ok {
+		return newIPv4Result(a & b)
+	}
 	int := big.NewInt(0).And(num.Int, v.Int)
-	return &IPNumber{int}
+	return newBigIPNumber(int, num.version)
 }
 
 // Lsh shifts num left by v bits and returns the result.
@@ -378,8 +1004,13 @@ func (num *IPNumber) And(v *IPNumber) *IPNumber {
 //	result := ipNum.Lsh(8)
 //	fmt.Println(result) // Output: 256
 func (num *IPNumber) Lsh(v uint) *IPNumber {
+	if a, ok := num.ipv4Value(); ok && v < 64 {
+		if a := uint64(a); bits.LeadingZeros64(a) >= int(v) {
+			return newIPv4Result(a << v)
+		}
+	}
 	int := big.NewInt(0).Lsh(num.Int, v)
-	return &IPNumber{int}
+	return newBigIPNumber(int, num.version)
 }
 
 // Neg returns the negative of num.
@@ -391,7 +1022,86 @@ func (num *IPNumber) Lsh(v uint) *IPNumber {
 //	fmt.Println(result) // Output: -1
 func (num *IPNumber) Neg() *IPNumber {
 	int := big.NewInt(0).Neg(num.Int)
-	return &IPNumber{int}
+	return newBigIPNumber(int, num.version)
+}
+
+// ipv4Number is the single allocation behind an IPv4 IPNumber. The embedded
+// big.Int uses word as its storage, so it costs no allocations of its own.
+type ipv4Number struct {
+	num  IPNumber
+	i    big.Int
+	word [1]big.Word
+}
+
+// newIPv4Number returns an IPv4 IPNumber for the passed value.
+func newIPv4Number(v uint32) *IPNumber {
+	n := &ipv4Number{}
+	n.word[0] = big.Word(v)
+	n.num = IPNumber{
+		Int:     n.i.SetBits(n.word[:]),
+		version: IPv4,
+	}
+	return &n.num
+}
+
+// newIPv4Result returns an IPv4 IPNumber for the result of a fast path
+// calculation, which may not fit in 32 bits, e.g. when adding one to
+// 255.255.255.255.
+func newIPv4Result(v uint64) *IPNumber {
+	if v <= math.MaxUint32 {
+		return newIPv4Number(uint32(v))
+	}
+	return &IPNumber{Int: new(big.Int).SetUint64(v), version: IPv4}
+}
+
+// newBigIPNumber returns an IPNumber of the passed version wrapping i. IPv4
+// numbers that fit in 32 bits, and zero, are normalised so that equal numbers
+// are also deeply equal, whether they were computed by big.Int or by the IPv4
+// fast path.
+func newBigIPNumber(i *big.Int, version *Version) *IPNumber {
+	if version == IPv4 && i.Sign() >= 0 && i.IsUint64() && i.Uint64() <= math.MaxUint32 {
+		return newIPv4Number(uint32(i.Uint64()))
+	}
+	if i.Sign() == 0 {
+		i = new(big.Int)
+	}
+	return &IPNumber{Int: i, version: version}
+}
+
+// ipv4Value returns the value of num as a uint32 when num is an IPv4 number
+// within the IPv4 address space. The value is read from the big.Int on every
+// call, so it is correct even if the big.Int was modified in place.
+func (num *IPNumber) ipv4Value() (uint32, bool) {
+	if num.version != IPv4 || num.Sign() < 0 || num.BitLen() > IPv4len*8 {
+		return 0, false
+	}
+	return uint32(num.Uint64()), true
+}
+
+// ipv4Operands returns num and v as uint64 values when num holds an IPv4
+// value and v is non-negative and fits in 64 bits. Callers use it to take a
+// fast path that avoids big.Int arithmetic; ok is false when the general
+// big.Int path must be used instead.
+func (num *IPNumber) ipv4Operands(v *IPNumber) (a, b uint64, ok bool) {
+	n, ok := num.ipv4Value()
+	if !ok || !v.IsUint64() {
+		return 0, 0, false
+	}
+	return uint64(n), v.Uint64(), true
+}
+
+// newIPv4Address returns the IPv4 address whose integer value is v, in a
+// single allocation.
+func newIPv4Address(v uint32) *IPAddress {
+	a := &struct {
+		addr  IPAddress
+		ip    net.IP
+		bytes [IPv4len]byte
+	}{}
+	binary.BigEndian.PutUint32(a.bytes[:], v)
+	a.ip = a.bytes[:]
+	a.addr = IPAddress{IP: &a.ip, version: IPv4}
+	return &a.addr
 }
 
 // MinAddress returns the smaller of two IP addresses.
@@ -409,6 +1119,8 @@ func MinAddress(addr1, addr2 *IPAddress) *IPAddress {
 }
 
 // LessThan compares two IPAddresses, returning true when ip is less than other.
+// Addresses are totally ordered with every IPv4 address before every IPv6
+// address, matching ByIPRanges, and by integer value within a version.
 //
 // Example usage:
 //
@@ -416,10 +1128,11 @@ func MinAddress(addr1, addr2 *IPAddress) *IPAddress {
 //	ip2 := netaddr.NewIP("192.168.1.2")
 //	fmt.Println(ip1.LessThan(ip2)) // Output: true
 func (ip *IPAddress) LessThan(other *IPAddress) bool {
-	return ip.ToInt().LessThan(other.ToInt())
+	return ip.Compare(other) < 0
 }
 
 // GreaterThan compares two IPAddresses, returning true when ip is greater than other.
+// Addresses are ordered as described by LessThan.
 //
 // Example usage:
 //
@@ -427,10 +1140,11 @@ func (ip *IPAddress) LessThan(other *IPAddress) bool {
 //	ip2 := netaddr.NewIP("192.168.1.1")
 //	fmt.Println(ip1.GreaterThan(ip2)) // Output: true
 func (ip *IPAddress) GreaterThan(other *IPAddress) bool {
-	return ip.ToInt().GreaterThan(other.ToInt())
+	return ip.Compare(other) > 0
 }
 
 // LessThanOrEqual compares two IPAddresses, returning true when ip is less than or equal to other.
+// Addresses are ordered as described by LessThan.
 //
 // Example usage:
 //
@@ -438,10 +1152,12 @@ func (ip *IPAddress) GreaterThan(other *IPAddress) bool {
 //	ip2 := netaddr.NewIP("192.168.1.2")
 //	fmt.Println(ip1.LessThanOrEqual(ip2)) // Output: true
 func (ip *IPAddress) LessThanOrEqual(other *IPAddress) bool {
-	return ip.ToInt().LessThanOrEqual(other.ToInt())
+	return ip.Compare(other) <= 0
 }
 
 // Equal compares two IPAddresses, returning true when ip is equal to other.
+// Addresses of different IP versions are never equal, even when their integer
+// values match, e.g. 0.0.0.1 and ::1; see NumericEqual.
 //
 // Example usage:
 //
@@ -449,10 +1165,23 @@ func (ip *IPAddress) LessThanOrEqual(other *IPAddress) bool {
 //	ip2 := netaddr.NewIP("192.168.1.1")
 //	fmt.Println(ip1.Equal(ip2)) // Output: true
 func (ip *IPAddress) Equal(other *IPAddress) bool {
+	return ip.Version() == other.Version() && ip.NumericEqual(other)
+}
+
+// NumericEqual compares the integer values of two IPAddresses, ignoring their
+// IP versions, so 0.0.0.1 and ::1 are numerically equal.
+//
+// Example usage:
+//
+//	ip1 := netaddr.NewIP("0.0.0.1")
+//	ip2 := netaddr.NewIP("::1")
+//	fmt.Println(ip1.NumericEqual(ip2)) // Output: true
+func (ip *IPAddress) NumericEqual(other *IPAddress) bool {
 	return ip.ToInt().Equal(other.ToInt())
 }
 
 // GreaterThanOrEqual compares two IPAddresses, returning true when ip is greater than or equal to other.
+// Addresses are ordered as described by LessThan.
 //
 // Example usage:
 //
@@ -460,5 +1189,204 @@ func (ip *IPAddress) Equal(other *IPAddress) bool {
 //	ip2 := netaddr.NewIP("192.168.1.1")
 //	fmt.Println(ip1.GreaterThanOrEqual(ip2)) // Output: true
 func (ip *IPAddress) GreaterThanOrEqual(other *IPAddress) bool {
-	return ip.ToInt().GreaterThanOrEqual(other.ToInt())
+	return ip.Compare(other) >= 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether ip sorts before, equal to
+// or after other, in the order described by LessThan. Addresses holding no
+// address sort before all others. It suits slices.SortFunc and sort.Slice
+// without comparing the addresses twice.
+//
+// Example usage:
+//
+//	ips := []*netaddr.IPAddress{netaddr.NewIP("::1"), netaddr.NewIP("10.0.0.1")}
+//	slices.SortFunc(ips, (*netaddr.IPAddress).Compare)
+//	fmt.Println(ips) // Output: [10.0.0.1 ::1]
+func (ip *IPAddress) Compare(other *IPAddress) int {
+	if v, o := ip.Version(), other.Version(); v != o {
+		if v == nil || (o != nil && v.LessThan(o)) {
+			return -1
+		}
+		return 1
+	}
+	return ip.ToInt().cmp(other.ToInt())
+}
+
+// IsPrivate returns true when ip is within a private address block, i.e. one
+// of the RFC 1918 IPv4 blocks or the IPv6 unique local block fc00::/7.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("192.168.1.1")
+//	fmt.Println(ip.IsPrivate()) // Output: true
+func (ip *IPAddress) IsPrivate() bool {
+	return ip.inAnyOf(privateNetworks)
+}
+
+// IsLoopback returns true when ip is a loopback address, within 127.0.0.0/8
+// for IPv4 or equal to ::1 for IPv6.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("127.0.0.1")
+//	fmt.Println(ip.IsLoopback()) // Output: true
+func (ip *IPAddress) IsLoopback() bool {
+	return ip.inAnyOf(loopbackNetworks)
+}
+
+// IsMulticast returns true when ip is a multicast address, within 224.0.0.0/4
+// for IPv4 or ff00::/8 for IPv6.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("224.0.0.1")
+//	fmt.Println(ip.IsMulticast()) // Output: true
+func (ip *IPAddress) IsMulticast() bool {
+	return ip.inAnyOf(multicastNetworks)
+}
+
+// IsLinkLocal returns true when ip is a link-local address, within
+// 169.254.0.0/16 for IPv4 or fe80::/10 for IPv6.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("169.254.0.1")
+//	fmt.Println(ip.IsLinkLocal()) // Output: true
+func (ip *IPAddress) IsLinkLocal() bool {
+	return ip.inAnyOf(linkLocalNetworks)
+}
+
+// IsUnspecified returns true when ip is the unspecified address, 0.0.0.0 for
+// IPv4 or :: for IPv6.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("0.0.0.0")
+//	fmt.Println(ip.IsUnspecified()) // Output: true
+func (ip *IPAddress) IsUnspecified() bool {
+	return ip.Version() != nil && ip.ToInt().Equal(NewIPNumber(0))
+}
+
+// IsGlobalUnicast returns true when ip is a global unicast address. As with
+// net.IP, this includes private addresses, but excludes the unspecified,
+// loopback, multicast, link-local and IPv4 limited broadcast addresses.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("8.8.8.8")
+//	fmt.Println(ip.IsGlobalUnicast()) // Output: true
+func (ip *IPAddress) IsGlobalUnicast() bool {
+	if ip.Version() == IPv4 && ip.ToInt().Equal(IPv4.max) {
+		return false
+	}
+	return !ip.IsUnspecified() &&
+		!ip.IsLoopback() &&
+		!ip.IsMulticast() &&
+		!ip.IsLinkLocal()
+}
+
+// IsInNetwork returns true when ip is within nw, like net.IPNet.Contains. It is
+// the address-side counterpart of IPNetwork.ContainsAddress.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	ip := netaddr.NewIP("192.168.1.100")
+//	fmt.Println(ip.IsInNetwork(nw)) // Output: true
+func (ip *IPAddress) IsInNetwork(nw *IPNetwork) bool {
+	return nw.ContainsAddress(ip)
+}
+
+// InAnyNetwork returns true when ip is within any of the passed networks.
+// Networks of a different IP version never contain ip.
+//
+// Example usage:
+//
+//	trusted := []*netaddr.IPNetwork{nw1, nw2}
+//	if ip.InAnyNetwork(trusted...) {
+//	    fmt.Println("trusted")
+//	}
+func (ip *IPAddress) InAnyNetwork(nets ...*IPNetwork) bool {
+	return ip.inAnyOf(nets)
+}
+
+// inAnyOf returns true when ip is contained by any of the networks of the
+// same version.
+func (ip *IPAddress) inAnyOf(networks []*IPNetwork) bool {
+	for _, nw := range networks {
+		if nw.version == ip.Version() && nw.ContainsAddress(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sixto4EmbeddedV4 returns the IPv4 address embedded in a 6to4 address, i.e.
+// bits 16 to 47 of an address within 2002::/16. An error is returned for
+// addresses outside of that prefix.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("2002:c000:0204::1")
+//	v4, err := ip.Sixto4EmbeddedV4()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(v4) // Output: "192.0.2.4"
+func (ip *IPAddress) Sixto4EmbeddedV4() (*IPAddress, error) {
+	if !ip.inAnyOf([]*IPNetwork{sixToFourNetwork}) {
+		return nil, fmt.Errorf("%s is not a 6to4 address within %s", ip, sixToFourNetwork)
+	}
+	return embeddedV4((*ip.IP)[2:6], 0), nil
+}
+
+// TeredoServer returns the IPv4 address of the Teredo server embedded in a
+// Teredo address, i.e. bits 32 to 63 of an address within 2001::/32. An error
+// is returned for addresses outside of that prefix.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("2001:0:4136:e378:8000:63bf:3fff:fdd2")
+//	server, err := ip.TeredoServer()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(server) // Output: "65.54.227.120"
+func (ip *IPAddress) TeredoServer() (*IPAddress, error) {
+	if !ip.inAnyOf([]*IPNetwork{teredoNetwork}) {
+		return nil, fmt.Errorf("%s is not a Teredo address within %s", ip, teredoNetwork)
+	}
+	return embeddedV4((*ip.IP)[4:8], 0), nil
+}
+
+// TeredoClient returns the public IPv4 address of the Teredo client embedded,
+// obfuscated by inverting every bit, in the final 32 bits of a Teredo address.
+// An error is returned for addresses outside of 2001::/32.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("2001:0:4136:e378:8000:63bf:3fff:fdd2")
+//	client, err := ip.TeredoClient()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(client) // Output: "192.0.2.45"
+func (ip *IPAddress) TeredoClient() (*IPAddress, error) {
+	if !ip.inAnyOf([]*IPNetwork{teredoNetwork}) {
+		return nil, fmt.Errorf("%s is not a Teredo address within %s", ip, teredoNetwork)
+	}
+	return embeddedV4((*ip.IP)[12:16], 0xff), nil
+}
+
+// embeddedV4 returns the IPv4 address held in the four passed bytes, each
+// XORed with mask.
+func embeddedV4(b []byte, mask byte) *IPAddress {
+	ip := make(net.IP, IPv4len)
+	for i := range ip {
+		ip[i] = b[i] ^ mask
+	}
+	return &IPAddress{
+		IP:      &ip,
+		version: IPv4,
+	}
 }
@@ -1,11 +1,32 @@
 package netaddr
 
 import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestVersionAccessors(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 4, IPv4.Number())
+	assert.Equal(t, 32, IPv4.BitLength())
+	assert.Equal(t, NewIP("255.255.255.255").ToInt(), IPv4.Max())
+
+	assert.Equal(t, 6, IPv6.Number())
+	assert.Equal(t, 128, IPv6.BitLength())
+	assert.Equal(t, NewIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff").ToInt(), IPv6.Max())
+
+	// Modifying the returned value must not change the version.
+	IPv4.Max().SetInt64(0)
+	assert.Equal(t, NewIP("255.255.255.255").ToInt(), IPv4.Max())
+}
+
 func TestIPAddressToIntConversion(t *testing.T) {
 	t.Parallel()
 
@@ -15,6 +36,9 @@ func TestIPAddressToIntConversion(t *testing.T) {
 		{NewIP("0.0.0.0")},
 		{NewIP("255.255.255.255")},
 		{NewIP("1.1.1.1")},
+		{NewIP("::")},
+		{NewIP("::1")},
+		{NewIP("2001:db8::")},
 	}
 
 	for _, test := range tests {
@@ -27,6 +51,328 @@ func TestIPAddressToIntConversion(t *testing.T) {
 
 }
 
+func TestValidIPV4(t *testing.T) {
+	t.Parallel()
+
+	allOnes := make([]byte, IPv6len)
+	for i := range allOnes {
+		allOnes[i] = 0xff
+	}
+
+	var tests = []struct {
+		ipBytes  []byte
+		expected bool
+	}{
+		{[]byte{}, true},
+		{[]byte{192, 168, 1, 1}, true},
+		{make([]byte, IPv6len), true},
+		{[]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 10, 0, 0, 1}, true},
+		{[]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, false},
+		{allOnes, false},
+		{make([]byte, 8), false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, ValidIPV4(test.ipBytes), "%v", test.ipBytes)
+	}
+
+	// A 16 byte number with no zero bytes in its first 12 bytes used to be
+	// reported as IPv4, so ToIPAddress truncated it to 255.255.255.255.
+	num := NewIPNumber(0)
+	num.SetBytes(allOnes)
+	ip := num.ToIPAddress()
+	assert.Equal(t, IPv6, ip.Version())
+	assert.Equal(t, "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", ip.String())
+}
+
+func TestIPNumberToIPAddressV(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr *IPAddress
+	}{
+		{NewIP("::1")},
+		{NewIP("::")},
+		{NewIP("::ffff:ffff")},
+		{NewIP("2001:db8::1")},
+		{NewIP("0.0.0.1")},
+		{NewIP("255.255.255.255")},
+	}
+
+	for _, test := range tests {
+		result := test.addr.ToInt().ToIPAddressV(test.addr.Version())
+		assert.Equal(t, test.addr, result)
+		assert.Equal(t, test.addr.Version(), result.Version())
+	}
+
+	// IPv4-mapped IPv6 addresses keep their IPv6 form.
+	mapped := NewIPNumber(0xffffc0a80101).ToIPAddressV(IPv6)
+	assert.Equal(t, "::ffff:192.168.1.1", mapped.String())
+	assert.Equal(t, "192.168.1.1", NewIPNumber(0xc0a80101).ToIPAddressV(IPv4).String())
+}
+
+func TestIPNumberIPv4FastPath(t *testing.T) {
+	t.Parallel()
+
+	// bigInt returns n without its version, so that every operation on it
+	// takes the big.Int path.
+	bigInt := func(n *IPNumber) *IPNumber {
+		return &IPNumber{Int: n.Int}
+	}
+
+	var tests = []struct {
+		a, b *IPAddress
+	}{
+		{NewIP("0.0.0.0"), NewIP("0.0.0.0")},
+		{NewIP("10.0.0.1"), NewIP("0.0.0.255")},
+		{NewIP("192.168.1.1"), NewIP("192.168.1.2")},
+		{NewIP("255.255.255.255"), NewIP("0.0.0.1")},
+		{NewIP("255.255.255.255"), NewIP("255.255.255.255")},
+	}
+
+	for _, test := range tests {
+		a, b := test.a.ToInt(), test.b.ToInt()
+		_, ok := a.ipv4Value()
+		assert.True(t, ok, "%s: ToInt must return an IPv4 value", test.a)
+
+		assert.Equal(t, bigInt(a).Add(bigInt(b)).String(), a.Add(b).String(), "%s + %s", test.a, test.b)
+		assert.Equal(t, bigInt(a).Sub(bigInt(b)).String(), a.Sub(b).String(), "%s - %s", test.a, test.b)
+		assert.Equal(t, bigInt(b).Sub(bigInt(a)).String(), b.Sub(a).String(), "%s - %s", test.b, test.a)
+		assert.Equal(t, bigInt(a).And(bigInt(b)).String(), a.And(b).String(), "%s & %s", test.a, test.b)
+		assert.Equal(t, bigInt(a).Lsh(8).String(), a.Lsh(8).String(), "%s << 8", test.a)
+		assert.Equal(t, bigInt(a).Cmp(b.Int), a.cmp(b), "cmp(%s, %s)", test.a, test.b)
+		assert.Equal(t, test.a, a.ToIPAddress())
+	}
+
+	// Results that no longer fit in 32 bits fall back to big.Int.
+	overflow := NewIP("255.255.255.255").ToInt().Add(NewIPNumber(1))
+	_, ok := overflow.ipv4Value()
+	assert.False(t, ok)
+	assert.Equal(t, "4294967296", overflow.String())
+
+	// The fast path reads the embedded big.Int, so it sees in place changes.
+	n := NewIP("10.0.0.1").ToInt()
+	n.SetInt64(5)
+	assert.True(t, n.Equal(NewIPNumber(5)))
+	assert.True(t, n.LessThan(NewIPNumber(6)))
+	assert.Equal(t, "0.0.0.5", n.ToIPAddress().String())
+	assert.Equal(t, "6", n.Add(NewIPNumber(1)).String())
+	n.SetInt64(1 << 40)
+	assert.True(t, n.GreaterThan(IPv4.Max()))
+	assert.Equal(t, "1099511627777", n.Add(NewIPNumber(1)).String())
+}
+
+func TestNewIPAddress(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected *IPAddress
+		wantErr  bool
+	}{
+		{"192.168.1.1", NewIP("192.168.1.1"), false},
+		{"2001:db8::1", NewIP("2001:db8::1"), false},
+		{"::ffff:192.168.1.1", NewIP("192.168.1.1"), false},
+		{"fe80::1%eth0", NewIP("fe80::1").WithZone("eth0"), false},
+		{"999.1.1.1", nil, true},
+		{"192.168.1.1%eth0", nil, true},
+		{"fe80::1%", nil, true},
+		{"hello", nil, true},
+		{"192.168.1.0/24", nil, true},
+		{"", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ip, err := NewIPAddress(test.input)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, ip)
+		})
+	}
+}
+
+func TestParseIntAddress(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		version  *Version
+		expected *IPAddress
+		wantErr  bool
+	}{
+		{"3232235777", IPv4, NewIP("192.168.1.1"), false},
+		{"0", IPv4, NewIP("0.0.0.0"), false},
+		{"4294967295", IPv4, NewIP("255.255.255.255"), false},
+		{"3232235777", IPv6, NewIP("::c0a8:101"), false},
+		{"340282366920938463463374607431768211455", IPv6, NewIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), false},
+		{"4294967296", IPv4, nil, true},
+		{"340282366920938463463374607431768211456", IPv6, nil, true},
+		{"-1", IPv4, nil, true},
+		{"192.168.1.1", IPv4, nil, true},
+		{"", IPv4, nil, true},
+		{"1", nil, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			ip, err := ParseIntAddress(test.input, test.version)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, ip)
+		})
+	}
+
+	_, err := NewIPFromInt(NewIPNumber(1<<32), IPv4)
+	assert.ErrorIs(t, err, ErrorAddressOutOFBounds)
+}
+
+func TestIPAddressCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	for _, addr := range []string{"0.0.0.0", "192.168.1.1", "255.255.255.255", "::", "::1", "2001:db8::1"} {
+		ip := NewIP(addr)
+		assert.Equal(t, ip, ip.ToInt().ToIPAddress(), "%s: ToInt().ToIPAddress()", addr)
+		assert.Equal(t, ip, ip.ToInt().ToIPAddressV(ip.Version()), "%s: ToInt().ToIPAddressV()", addr)
+	}
+
+	mapped := net.ParseIP("::ffff:192.168.1.1")
+	var tests = []struct {
+		raw      net.IP
+		version  *Version
+		expected *IPAddress
+	}{
+		{net.ParseIP("192.168.1.1"), nil, NewIP("192.168.1.1")},
+		{net.ParseIP("192.168.1.1").To4(), nil, NewIP("192.168.1.1")},
+		{net.ParseIP("2001:db8::1"), nil, NewIP("2001:db8::1")},
+		// An explicit version is kept, even for IPv4-mapped addresses.
+		{mapped, IPv6, &IPAddress{IP: &mapped, version: IPv6}},
+		{mapped.To4(), IPv6, &IPAddress{IP: &mapped, version: IPv6}},
+	}
+
+	for _, test := range tests {
+		raw := append(net.IP{}, test.raw...)
+		ip := &IPAddress{IP: &raw, version: test.version}
+		ip.Canonicalize()
+		assert.Equal(t, test.expected, ip, "%s", test.raw)
+	}
+
+	invalid := NewIP("invalid")
+	invalid.Canonicalize()
+	assert.Equal(t, NewIP("invalid"), invalid)
+}
+
+func TestInvalidIPAddressDoesNotPanic(t *testing.T) {
+	t.Parallel()
+
+	for _, ip := range []*IPAddress{NewIP("garbage"), NewIP(""), {}} {
+		assert.NotPanics(t, func() {
+			assert.Nil(t, ip.Version())
+			assert.Equal(t, "<nil>", ip.String())
+			assert.True(t, NewIPNumber(0).Equal(ip.ToInt()))
+			assert.Equal(t, "", ip.Bits())
+			assert.Equal(t, "", ip.Hex())
+			assert.False(t, ip.IsUnspecified())
+
+			result, err := ip.Increment(NewIPNumber(1))
+			assert.ErrorIs(t, err, ErrorInvalidAddress)
+			assert.Nil(t, result)
+		})
+	}
+
+	_, err := NewIPAddress("garbage")
+	assert.Error(t, err)
+}
+
+func TestIPAddressEqual(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		ip           *IPAddress
+		other        *IPAddress
+		equal        bool
+		numericEqual bool
+	}{
+		{NewIP("192.168.1.1"), NewIP("192.168.1.1"), true, true},
+		{NewIP("192.168.1.1"), NewIP("192.168.1.2"), false, false},
+		{NewIP("::1"), NewIP("0.0.0.1"), false, true},
+		{NewIP("0.0.0.0"), NewIP("::"), false, true},
+		{NewIP("2001:db8::1"), NewIP("2001:db8::1"), true, true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.equal, test.ip.Equal(test.other), "%s Equal %s", test.ip, test.other)
+		assert.Equal(t, test.numericEqual, test.ip.NumericEqual(test.other), "%s NumericEqual %s", test.ip, test.other)
+	}
+}
+
+func TestIPAddressOrdering(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		ip    *IPAddress
+		other *IPAddress
+		less  bool
+		equal bool
+	}{
+		{NewIP("192.168.1.1"), NewIP("192.168.1.2"), true, false},
+		{NewIP("192.168.1.2"), NewIP("192.168.1.1"), false, false},
+		{NewIP("192.168.1.1"), NewIP("192.168.1.1"), false, true},
+		{NewIP("255.255.255.255"), NewIP("::"), true, false},
+		{NewIP("::1"), NewIP("0.0.0.1"), false, false},
+		{NewIP("::ffff"), NewIP("10.0.0.0"), false, false},
+		{NewIP("2001:db8::1"), NewIP("2001:db8::2"), true, false},
+	}
+
+	for _, test := range tests {
+		greater := !test.less && !test.equal
+		assert.Equal(t, test.less, test.ip.LessThan(test.other), "%s LessThan %s", test.ip, test.other)
+		assert.Equal(t, test.less || test.equal, test.ip.LessThanOrEqual(test.other), "%s LessThanOrEqual %s", test.ip, test.other)
+		assert.Equal(t, greater, test.ip.GreaterThan(test.other), "%s GreaterThan %s", test.ip, test.other)
+		assert.Equal(t, greater || test.equal, test.ip.GreaterThanOrEqual(test.other), "%s GreaterThanOrEqual %s", test.ip, test.other)
+
+		expected := 0
+		if test.less {
+			expected = -1
+		} else if greater {
+			expected = 1
+		}
+		assert.Equal(t, expected, test.ip.Compare(test.other), "%s Compare %s", test.ip, test.other)
+	}
+}
+
+func TestIPAddressCompareSort(t *testing.T) {
+	t.Parallel()
+
+	ips := []*IPAddress{
+		NewIP("2001:db8::1"),
+		NewIP("10.0.0.2"),
+		NewIP("::1"),
+		NewIP("invalid"),
+		NewIP("192.168.1.1"),
+		NewIP("10.0.0.1"),
+		NewIP("::ffff:a00:1"),
+	}
+	first, second := ips[5], ips[6]
+	slices.SortStableFunc(ips, (*IPAddress).Compare)
+
+	var sorted []string
+	for _, ip := range ips {
+		sorted = append(sorted, ip.String())
+	}
+	assert.Equal(t, []string{"<nil>", "10.0.0.1", "10.0.0.1", "10.0.0.2", "192.168.1.1", "::1", "2001:db8::1"}, sorted)
+
+	// Equal addresses keep their original order.
+	assert.Same(t, first, ips[1])
+	assert.Same(t, second, ips[2])
+}
+
 func TestIncrement(t *testing.T) {
 	t.Parallel()
 
@@ -41,6 +387,12 @@ func TestIncrement(t *testing.T) {
 		{NewIP("1.1.1.255"), 1, NewIP("1.1.2.0"), nil},
 		{NewIP("1.1.1.254"), 3, NewIP("1.1.2.1"), nil},
 		{NewIP("255.255.255.255"), 1, nil, ErrorAddressOutOFBounds},
+		{NewIP("0.0.0.0"), 1, NewIP("0.0.0.1"), nil},
+		{NewIP("0.0.0.0"), 256, NewIP("0.0.1.0"), nil},
+		{NewIP("0.0.0.0"), -1, nil, ErrorAddressOutOFBounds},
+		{NewIP("::1"), 1, NewIP("::2"), nil},
+		{NewIP("2001:db8::"), 1, NewIP("2001:db8::1"), nil},
+		{NewIP("::"), 1, NewIP("::1"), nil},
 	}
 
 	for _, test := range tests {
@@ -50,3 +402,481 @@ func TestIncrement(t *testing.T) {
 	}
 
 }
+
+func TestIncrementDoesNotMutate(t *testing.T) {
+	t.Parallel()
+
+	ip := NewIP("10.0.0.1")
+	result, err := ip.Increment(NewIPNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, NewIP("10.0.0.2"), result)
+	assert.Equal(t, NewIP("10.0.0.1"), ip)
+}
+
+func TestIPAddressAddAndSub(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name          string
+		ip            *IPAddress
+		n             int64
+		added         *IPAddress
+		subtracted    *IPAddress
+		expectedError error
+	}{
+		{"ipv4", NewIP("10.0.0.10"), 5, NewIP("10.0.0.15"), NewIP("10.0.0.5"), nil},
+		{"ipv4 octet carry", NewIP("10.0.0.255"), 1, NewIP("10.0.1.0"), NewIP("10.0.0.254"), nil},
+		{"ipv6", NewIP("2001:db8::10"), 16, NewIP("2001:db8::20"), NewIP("2001:db8::"), nil},
+		{"zero", NewIP("0.0.0.0"), 0, NewIP("0.0.0.0"), NewIP("0.0.0.0"), nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			added, err := test.ip.Add(NewIPNumber(test.n))
+			assert.NoError(t, err)
+			assert.Equal(t, test.added, added)
+
+			subtracted, err := test.ip.Sub(NewIPNumber(test.n))
+			assert.NoError(t, err)
+			assert.Equal(t, test.subtracted, subtracted)
+		})
+	}
+}
+
+func TestIPAddressAddAndSubBounds(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name string
+		ip   *IPAddress
+		add  int64
+	}{
+		{"past ipv4 max", NewIP("255.255.255.255"), 1},
+		{"below ipv4 zero", NewIP("0.0.0.0"), -1},
+		{"past ipv6 max", NewIP("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"), 1},
+		{"below ipv6 zero", NewIP("::"), -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.ip.Add(NewIPNumber(test.add))
+			assert.ErrorIs(t, err, ErrorAddressOutOFBounds)
+			assert.Nil(t, result)
+
+			result, err = test.ip.Sub(NewIPNumber(-test.add))
+			assert.ErrorIs(t, err, ErrorAddressOutOFBounds)
+			assert.Nil(t, result)
+		})
+	}
+
+	// An offset beyond the IPv4 space errors rather than producing an IPv6
+	// address.
+	_, err := NewIP("0.0.0.0").Add(NewIP("::1:0:0").ToInt())
+	assert.ErrorIs(t, err, ErrorAddressOutOFBounds)
+}
+
+func TestIPAddressClassification(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr          *IPAddress
+		private       bool
+		loopback      bool
+		multicast     bool
+		linkLocal     bool
+		unspecified   bool
+		globalUnicast bool
+	}{
+		{NewIP("10.1.2.3"), true, false, false, false, false, true},
+		{NewIP("172.16.0.1"), true, false, false, false, false, true},
+		{NewIP("192.168.1.1"), true, false, false, false, false, true},
+		{NewIP("127.0.0.1"), false, true, false, false, false, false},
+		{NewIP("224.0.0.1"), false, false, true, false, false, false},
+		{NewIP("169.254.1.1"), false, false, false, true, false, false},
+		{NewIP("0.0.0.0"), false, false, false, false, true, false},
+		{NewIP("255.255.255.255"), false, false, false, false, false, false},
+		{NewIP("8.8.8.8"), false, false, false, false, false, true},
+		{NewIP("fd00::1"), true, false, false, false, false, true},
+		{NewIP("::1"), false, true, false, false, false, false},
+		{NewIP("ff02::1"), false, false, true, false, false, false},
+		{NewIP("fe80::1"), false, false, false, true, false, false},
+		{NewIP("::"), false, false, false, false, true, false},
+		{NewIP("2001:4860:4860::8888"), false, false, false, false, false, true},
+		{NewIP("::7f00:1"), false, false, false, false, false, true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.private, test.addr.IsPrivate(), "%s: IsPrivate", test.addr)
+		assert.Equal(t, test.loopback, test.addr.IsLoopback(), "%s: IsLoopback", test.addr)
+		assert.Equal(t, test.multicast, test.addr.IsMulticast(), "%s: IsMulticast", test.addr)
+		assert.Equal(t, test.linkLocal, test.addr.IsLinkLocal(), "%s: IsLinkLocal", test.addr)
+		assert.Equal(t, test.unspecified, test.addr.IsUnspecified(), "%s: IsUnspecified", test.addr)
+		assert.Equal(t, test.globalUnicast, test.addr.IsGlobalUnicast(), "%s: IsGlobalUnicast", test.addr)
+	}
+}
+
+func TestIPAddressIsInNetwork(t *testing.T) {
+	t.Parallel()
+
+	nw := newTestNetwork(t, "192.168.1.0/24")
+	other := newTestNetwork(t, "10.0.0.0/8")
+	v6 := newTestNetwork(t, "::/0")
+
+	var tests = []struct {
+		addr     *IPAddress
+		expected bool
+	}{
+		{NewIP("192.168.1.0"), true},
+		{NewIP("192.168.1.255"), true},
+		{NewIP("192.168.0.255"), false},
+		{NewIP("192.168.2.0"), false},
+		{NewIP("::ffff:c0a8:0101"), true},
+		{NewIP("2001:db8::1"), false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.addr.IsInNetwork(nw), "%s: IsInNetwork", test.addr)
+		assert.Equal(t, test.expected, test.addr.InAnyNetwork(other, nw), "%s: InAnyNetwork", test.addr)
+	}
+
+	assert.True(t, NewIP("10.255.255.255").InAnyNetwork(nw, other))
+	assert.False(t, NewIP("10.0.0.1").InAnyNetwork())
+	assert.False(t, NewIP("10.0.0.1").InAnyNetwork(v6))
+	assert.True(t, NewIP("2001:db8::1").InAnyNetwork(nw, v6))
+}
+
+func TestIPAddressJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr *IPAddress
+		json string
+	}{
+		{NewIP("192.168.1.1"), `"192.168.1.1"`},
+		{NewIP("2001:db8::1"), `"2001:db8::1"`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.addr)
+		assert.NoError(t, err)
+		assert.Equal(t, test.json, string(data))
+
+		var result IPAddress
+		assert.NoError(t, json.Unmarshal(data, &result))
+		assert.Equal(t, test.addr, &result)
+		assert.Equal(t, test.addr.Version(), result.Version())
+	}
+}
+
+func TestIPAddressUnmarshalTextInvalid(t *testing.T) {
+	t.Parallel()
+
+	var result IPAddress
+	err := result.UnmarshalText([]byte("999.1.1.1"))
+	assert.EqualError(t, err, `invalid ip address: "999.1.1.1"`)
+}
+
+func TestIPAddressScanAndValue(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		src      any
+		expected *IPAddress
+		wantErr  bool
+	}{
+		{"bytes", []byte("192.168.1.1"), NewIP("192.168.1.1"), false},
+		{"string", "2001:db8::1", NewIP("2001:db8::1"), false},
+		{"null", nil, &IPAddress{}, false},
+		{"invalid string", "hello", nil, true},
+		{"unsupported type", 42, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var result IPAddress
+			err := result.Scan(test.src)
+			if (err != nil) != test.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, test.wantErr)
+				return
+			}
+			if test.wantErr {
+				return
+			}
+			assert.Equal(t, test.expected, &result)
+		})
+	}
+
+	value, err := NewIP("192.168.1.1").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.1.1", value)
+
+	// NULL round trips.
+	var ip IPAddress
+	assert.NoError(t, ip.Scan(nil))
+	value, err = ip.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestNetipAddrRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr  *IPAddress
+		netip netip.Addr
+	}{
+		{NewIP("192.168.1.1"), netip.MustParseAddr("192.168.1.1")},
+		{NewIP("2001:db8::1"), netip.MustParseAddr("2001:db8::1")},
+		{NewIP("fe80::1%eth0"), netip.MustParseAddr("fe80::1%eth0")},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.netip, test.addr.ToNetipAddr())
+		assert.Equal(t, test.addr, FromNetipAddr(test.netip))
+		assert.Equal(t, test.addr, FromNetipAddr(test.addr.ToNetipAddr()))
+	}
+
+	mapped := FromNetipAddr(netip.MustParseAddr("::ffff:192.168.1.1"))
+	assert.Equal(t, NewIP("192.168.1.1"), mapped)
+	assert.Equal(t, IPv4, mapped.Version())
+
+	assert.Nil(t, FromNetipAddr(netip.Addr{}))
+	assert.Equal(t, netip.Addr{}, (&IPAddress{}).ToNetipAddr())
+	assert.Equal(t, netip.Addr{}, NewIP("garbage").ToNetipAddr())
+}
+
+func TestIPAddressBitsAndHex(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr *IPAddress
+		bits string
+		hex  string
+	}{
+		{NewIP("0.0.0.0"), strings.Repeat("0", 32), "00000000"},
+		{NewIP("255.255.255.255"), strings.Repeat("1", 32), "ffffffff"},
+		{NewIP("192.168.1.1"), "11000000101010000000000100000001", "c0a80101"},
+		{NewIP("::1"), strings.Repeat("0", 127) + "1", strings.Repeat("0", 31) + "1"},
+		{NewIP("2001:db8::1"), "0010000000000001" + "0000110110111000" + strings.Repeat("0", 95) + "1", "20010db8000000000000000000000001"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.bits, test.addr.Bits(), "%s: Bits", test.addr)
+		assert.Equal(t, test.hex, test.addr.Hex(), "%s: Hex", test.addr)
+	}
+}
+
+func TestIPAddressBinaryGrouped(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr   *IPAddress
+		sep    string
+		groups int
+		width  int
+	}{
+		{NewIP("192.168.1.1"), ".", 4, 8},
+		{NewIP("2001:db8::1"), ":", 8, 16},
+	}
+
+	for _, test := range tests {
+		grouped := test.addr.BinaryGrouped()
+		groups := strings.Split(grouped, test.sep)
+		assert.Len(t, groups, test.groups, "%s: group count", test.addr)
+		for _, group := range groups {
+			assert.Len(t, group, test.width, "%s: group width", test.addr)
+		}
+		assert.Equal(t, test.addr.Bits(), strings.Join(groups, ""), "%s: Bits", test.addr)
+	}
+
+	assert.Equal(t, "11000000.10101000.00000001.00000001", NewIP("192.168.1.1").BinaryGrouped())
+	assert.Equal(t, "", NewIP("invalid").BinaryGrouped())
+}
+
+func TestIPAddressBitAtAndWithBit(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr    *IPAddress
+		bit     int
+		value   int
+		flipped string
+	}{
+		{NewIP("128.0.0.0"), 0, 1, "0.0.0.0"},
+		{NewIP("128.0.0.0"), 1, 0, "192.0.0.0"},
+		{NewIP("128.0.0.0"), 31, 0, "128.0.0.1"},
+		{NewIP("0.0.0.1"), 31, 1, "0.0.0.0"},
+		{NewIP("0.0.0.1"), 0, 0, "128.0.0.1"},
+		{NewIP("0.0.0.1"), 24, 0, "0.0.0.129"},
+		{NewIP("::1"), 127, 1, "::"},
+		{NewIP("::1"), 0, 0, "8000::1"},
+	}
+
+	for _, test := range tests {
+		original := test.addr.String()
+		bit, err := test.addr.BitAt(test.bit)
+		assert.NoError(t, err)
+		assert.Equal(t, test.value, bit, "%s: BitAt(%d)", test.addr, test.bit)
+
+		flipped, err := test.addr.WithBit(test.bit, 1-test.value)
+		assert.NoError(t, err)
+		assert.Equal(t, test.flipped, flipped.String(), "%s: WithBit(%d)", test.addr, test.bit)
+		assert.Equal(t, test.addr.Version(), flipped.Version())
+		bit, err = flipped.BitAt(test.bit)
+		assert.NoError(t, err)
+		assert.Equal(t, 1-test.value, bit)
+		assert.Equal(t, original, test.addr.String(), "WithBit must not mutate the receiver")
+	}
+
+	var errTests = []struct {
+		addr     *IPAddress
+		bit      int
+		expected error
+	}{
+		{NewIP("0.0.0.1"), 32, ErrorBitOutOfRange},
+		{NewIP("0.0.0.1"), -1, ErrorBitOutOfRange},
+		{NewIP("::1"), 128, ErrorBitOutOfRange},
+		{NewIP("garbage"), 0, ErrorInvalidAddress},
+		{&IPAddress{}, 0, ErrorInvalidAddress},
+	}
+
+	for _, test := range errTests {
+		_, err := test.addr.BitAt(test.bit)
+		assert.ErrorIs(t, err, test.expected, "BitAt(%d)", test.bit)
+		result, err := test.addr.WithBit(test.bit, 1)
+		assert.ErrorIs(t, err, test.expected, "WithBit(%d)", test.bit)
+		assert.Nil(t, result)
+	}
+}
+
+func TestIPAddressMask(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr     *IPAddress
+		mask     *IPMask
+		expected *IPAddress
+	}{
+		{NewIP("192.168.1.130"), NewMask(24, 32), NewIP("192.168.1.0")},
+		{NewIP("192.168.1.130"), NewMask(25, 32), NewIP("192.168.1.128")},
+		{NewIP("192.168.1.130"), NewMask(0, 32), NewIP("0.0.0.0")},
+		{NewIP("192.168.1.130"), NewMask(32, 32), NewIP("192.168.1.130")},
+		{NewIP("2001:db8::1"), NewMask(32, 128), NewIP("2001:db8::")},
+		{NewIP("192.168.1.130"), NewMask(120, 128), nil},
+		{NewIP("2001:db8::1"), NewMask(24, 32), nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.addr.Mask(test.mask), "%s masked with %s", test.addr, test.mask)
+	}
+}
+
+func TestIPAddressWords(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr  *IPAddress
+		words []int
+	}{
+		{NewIP("192.168.1.1"), []int{192, 168, 1, 1}},
+		{NewIP("0.0.0.0"), []int{0, 0, 0, 0}},
+		{NewIP("2001:db8::1"), []int{0x2001, 0xdb8, 0, 0, 0, 0, 0, 1}},
+		{NewIP("ffff::ffff"), []int{0xffff, 0, 0, 0, 0, 0, 0, 0xffff}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.words, test.addr.Words(), "%s: Words", test.addr)
+	}
+}
+
+func TestIPAddressStringExpandedAndCompressed(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr       *IPAddress
+		expanded   string
+		compressed string
+	}{
+		{NewIP("2001:db8::1"), "2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1"},
+		{NewIP("2001:0db8:0000:0000:0000:0000:0000:0001"), "2001:0db8:0000:0000:0000:0000:0000:0001", "2001:db8::1"},
+		{NewIP("::"), "0000:0000:0000:0000:0000:0000:0000:0000", "::"},
+		{NewIP("192.168.1.1"), "192.168.1.1", "192.168.1.1"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expanded, test.addr.StringExpanded(), "%s: StringExpanded", test.addr)
+		assert.Equal(t, test.compressed, test.addr.StringCompressed(), "%s: StringCompressed", test.addr)
+	}
+}
+
+func TestIPAddressZone(t *testing.T) {
+	t.Parallel()
+
+	ip := NewIP("fe80::1%eth0")
+	assert.Equal(t, IPv6, ip.Version())
+	assert.Equal(t, "eth0", ip.Zone())
+	assert.Equal(t, "fe80::1%eth0", ip.String())
+	assert.Equal(t, "fe80:0000:0000:0000:0000:0000:0000:0001%eth0", ip.StringExpanded())
+
+	data, err := json.Marshal(ip)
+	assert.NoError(t, err)
+	assert.Equal(t, `"fe80::1%eth0"`, string(data))
+	var decoded IPAddress
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, ip, &decoded)
+
+	// Comparisons ignore the zone.
+	unzoned := ip.WithZone("")
+	assert.Equal(t, "fe80::1", unzoned.String())
+	assert.Equal(t, "eth0", ip.Zone(), "WithZone must not mutate the receiver")
+	assert.True(t, ip.Equal(unzoned))
+	assert.True(t, ip.Equal(NewIP("fe80::1%eth1")))
+	assert.True(t, ip.LessThan(NewIP("fe80::2")))
+
+	// Arithmetic preserves the zone.
+	next, err := ip.Add(NewIPNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "fe80::2%eth0", next.String())
+	assert.Equal(t, "fe80::%eth0", ip.Mask(NewMask(64, 128)).String())
+
+	// Only IPv6 addresses carry a zone.
+	assert.Equal(t, "", NewIP("192.168.1.1").WithZone("eth0").Zone())
+}
+
+func TestSixto4EmbeddedV4(t *testing.T) {
+	t.Parallel()
+
+	v4, err := NewIP("2002:c000:0204::").Sixto4EmbeddedV4()
+	assert.NoError(t, err)
+	assert.Equal(t, NewIP("192.0.2.4"), v4)
+
+	v4, err = NewIP("2002:c000:0204:1::1").Sixto4EmbeddedV4()
+	assert.NoError(t, err)
+	assert.Equal(t, NewIP("192.0.2.4"), v4)
+
+	_, err = NewIP("2001:db8::1").Sixto4EmbeddedV4()
+	assert.Error(t, err)
+
+	_, err = NewIP("192.0.2.4").Sixto4EmbeddedV4()
+	assert.Error(t, err)
+}
+
+func TestTeredo(t *testing.T) {
+	t.Parallel()
+
+	ip := NewIP("2001:0000:4136:e378:8000:63bf:3fff:fdd2")
+	server, err := ip.TeredoServer()
+	assert.NoError(t, err)
+	assert.Equal(t, NewIP("65.54.227.120"), server)
+
+	client, err := ip.TeredoClient()
+	assert.NoError(t, err)
+	assert.Equal(t, NewIP("192.0.2.45"), client)
+
+	_, err = NewIP("2002:c000:0204::").TeredoServer()
+	assert.Error(t, err)
+
+	_, err = NewIP("2001:db8::1").TeredoClient()
+	assert.Error(t, err)
+}
@@ -1,11 +1,22 @@
 package netaddr
 
 import (
+	"cmp"
+	"database/sql/driver"
 	"fmt"
-	"math"
+	"iter"
 	"math/big"
+	"math/bits"
+	"math/rand"
 	"net"
-	"sort"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrorNoBroadcast is an error returned when the broadcast address of an IPv6 network is requested.
+	ErrorNoBroadcast = fmt.Errorf("ipv6 networks have no broadcast address")
 )
 
 // IPNetwork defines an IPAddress network, including version and mask.
@@ -23,10 +34,102 @@ type IPNetwork struct {
 //	fmt.Println(nw.String()) // Output: "192.168.1.0/24"
 func (nw *IPNetwork) String() string {
 	ones, _ := nw.Mask.Size()
-	return fmt.Sprintf("%s/%d", nw.start.ToIPAddress(), ones)
+	return fmt.Sprintf("%s/%d", nw.First(), ones)
+}
+
+// Key returns a canonical string identifying the network, suitable as a map
+// key for deduplicating networks. Equal networks have equal keys, however
+// they were created, and networks of different IP versions never share a
+// key. The key is the zero padded hexadecimal network address followed by the
+// prefix length, e.g. "c0a80100/24"; use String for display.
+//
+// Example usage:
+//
+//	seen := map[string]struct{}{}
+//	for _, nw := range networks {
+//	    seen[nw.Key()] = struct{}{}
+//	}
+func (nw *IPNetwork) Key() string {
+	return fmt.Sprintf("%s/%d", nw.First().Hex(), nw.Mask.PrefixLen())
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the canonical
+// "addr/prefix" representation of the network produced by String.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("2001:db8::/32")
+//	data, _ := json.Marshal(nw)
+//	fmt.Println(string(data)) // Output: "\"2001:db8::/32\""
+func (nw *IPNetwork) MarshalText() ([]byte, error) {
+	return []byte(nw.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing text as a CIDR in
+// the same way as NewIPNetwork.
+//
+// Example usage:
+//
+//	var nw netaddr.IPNetwork
+//	if err := json.Unmarshal([]byte(`"2001:db8::/32"`), &nw); err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(nw.String()) // Output: "2001:db8::/32"
+func (nw *IPNetwork) UnmarshalText(text []byte) error {
+	parsed, err := NewIPNetwork(string(text))
+	if err != nil {
+		return err
+	}
+	*nw = *parsed
+	return nil
 }
 
-// NewIPNetwork creates a new IPNetwork from a CIDR string.
+// Scan implements sql.Scanner, parsing the network from a string or []byte
+// database value such as a Postgres cidr column. Scanning NULL resets nw to
+// the zero IPNetwork.
+//
+// Example usage:
+//
+//	var nw netaddr.IPNetwork
+//	err := db.QueryRow("SELECT network FROM subnets").Scan(&nw)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+func (nw *IPNetwork) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*nw = IPNetwork{}
+		return nil
+	case string:
+		return nw.UnmarshalText([]byte(v))
+	case []byte:
+		return nw.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into IPNetwork", src)
+	}
+}
+
+// Value implements driver.Valuer, returning the canonical "addr/prefix"
+// representation of the network. The zero IPNetwork, such as one scanned from
+// NULL, is written as NULL.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	_, err := db.Exec("INSERT INTO subnets (network) VALUES ($1)", nw)
+func (nw *IPNetwork) Value() (driver.Value, error) {
+	if nw == nil || nw.start == nil || nw.Mask == nil || nw.Mask.IPMask == nil {
+		return nil, nil
+	}
+	return nw.String(), nil
+}
+
+// NewIPNetwork creates a new IPNetwork from a CIDR string. Parsing is lenient:
+// any host bits set in the address are cleared, so "192.168.1.5/24" yields the
+// network 192.168.1.0/24. Use NewIPNetworkStrict to reject such input.
+//
+// IPv4-mapped IPv6 prefixes such as "::ffff:192.0.2.0/120" are kept as IPv6
+// networks; use Unmap to convert them to the IPv4 network they embed.
 //
 // Example usage:
 //
@@ -57,6 +160,166 @@ func NewIPNetwork(cidr string) (*IPNetwork, error) {
 	}, nil
 }
 
+// IsIPv4Mapped returns true when nw is an IPv6 network within the
+// IPv4-mapped prefix ::ffff:0:0/96, e.g. ::ffff:192.0.2.0/120.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("::ffff:192.0.2.0/120")
+//	fmt.Println(nw.IsIPv4Mapped()) // Output: true
+func (nw *IPNetwork) IsIPv4Mapped() bool {
+	if nw.version != IPv6 || nw.Mask.PrefixLen() < 96 {
+		return false
+	}
+	return new(big.Int).Rsh(nw.start.Int, 32).Cmp(big.NewInt(0xffff)) == 0
+}
+
+// Unmap returns the IPv4 network embedded in an IPv4-mapped IPv6 network, so
+// ::ffff:192.0.2.0/120 yields 192.0.2.0/24. Any other network is returned
+// unchanged.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("::ffff:192.0.2.0/120")
+//	fmt.Println(nw.Unmap()) // Output: "192.0.2.0/24"
+func (nw *IPNetwork) Unmap() *IPNetwork {
+	if !nw.IsIPv4Mapped() {
+		return nw
+	}
+	return &IPNetwork{
+		start:   newIPv4Number(uint32(nw.start.Uint64())),
+		version: IPv4,
+		Mask:    NewMask(int64(nw.Mask.PrefixLen()-96), IPv4len*8),
+	}
+}
+
+// NewIPNetworkStrict creates a new IPNetwork from a CIDR string, returning an
+// error if the address has any bits set below the prefix, e.g. "192.168.1.5/24".
+//
+// Example usage:
+//
+//	nw, err := netaddr.NewIPNetworkStrict("192.168.1.0/24")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(nw)
+func NewIPNetworkStrict(cidr string) (*IPNetwork, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if !ip.Equal(network.IP) {
+		return nil, fmt.Errorf("address %s has host bits set for network %s", ip, network)
+	}
+	return NewIPNetwork(cidr)
+}
+
+// NewIPNetworkFromMask creates a new IPNetwork from an address and a netmask
+// in address form, e.g. "192.168.1.0" and "255.255.255.0". An error is
+// returned if the mask is not contiguous, e.g. "255.0.255.0", or is of a
+// different IP version to the address.
+//
+// Example usage:
+//
+//	nw, err := netaddr.NewIPNetworkFromMask("192.168.1.0", "255.255.255.0")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(nw) // Output: "192.168.1.0/24"
+func NewIPNetworkFromMask(addr string, mask string) (*IPNetwork, error) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid ip address: %q", addr)
+	}
+	maskIP := net.ParseIP(mask)
+	if maskIP == nil {
+		return nil, fmt.Errorf("invalid netmask: %q", mask)
+	}
+
+	ipBits, maskBits := IPv6len*8, IPv6len*8
+	if ip.To4() != nil {
+		ipBits = IPv4len * 8
+	}
+	if maskIP.To4() != nil {
+		maskIP = maskIP.To4()
+		maskBits = IPv4len * 8
+	}
+	if ipBits != maskBits {
+		return nil, fmt.Errorf("version of address %s and netmask %s don't match", addr, mask)
+	}
+
+	ones, bits := net.IPMask(maskIP).Size()
+	if bits == 0 {
+		return nil, fmt.Errorf("netmask %s is not contiguous", mask)
+	}
+	return NewIPNetwork(fmt.Sprintf("%s/%d", ip, ones))
+}
+
+// ParseIPOrCIDR parses s as either a CIDR, e.g. "192.168.1.0/24", or a single
+// address, e.g. "192.168.1.1". Exactly one of the returned address and
+// network is non-nil, telling the caller which form s was in. An error is
+// returned if s is neither.
+//
+// Example usage:
+//
+//	ip, nw, err := netaddr.ParseIPOrCIDR("192.168.1.0/24")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(ip, nw) // Output: <nil> 192.168.1.0/24
+func ParseIPOrCIDR(s string) (*IPAddress, *IPNetwork, error) {
+	if strings.Contains(s, "/") {
+		nw, err := NewIPNetwork(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nw, nil
+	}
+	ip := NewIP(s)
+	if ip.Version() == nil {
+		return nil, nil, fmt.Errorf("invalid ip address or cidr: %q", s)
+	}
+	return ip, nil, nil
+}
+
+// mustNewIPNetwork is like NewIPNetwork but panics if the CIDR cannot be
+// parsed. It simplifies the initialisation of package-level networks.
+func mustNewIPNetwork(cidr string) *IPNetwork {
+	nw, err := NewIPNetwork(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return nw
+}
+
+// FromNetipPrefix returns a new IPNetwork for the passed netip.Prefix. As with
+// NewIPNetwork, any host bits set in the prefix's address are cleared.
+//
+// Example usage:
+//
+//	nw, err := netaddr.FromNetipPrefix(netip.MustParsePrefix("192.168.1.0/24"))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(nw) // Output: "192.168.1.0/24"
+func FromNetipPrefix(p netip.Prefix) (*IPNetwork, error) {
+	if !p.IsValid() {
+		return nil, fmt.Errorf("invalid prefix: %s", p)
+	}
+	return NewIPNetwork(p.Masked().String())
+}
+
+// ToNetipPrefix returns the netip.Prefix equivalent of the IPNetwork.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.ToNetipPrefix()) // Output: "192.168.1.0/24"
+func (nw *IPNetwork) ToNetipPrefix() netip.Prefix {
+	ones, _ := nw.Mask.Size()
+	return netip.PrefixFrom(nw.First().ToNetipAddr(), ones)
+}
+
 // newNetworkFromBoundaries creates a new IPNetwork from two IP addresses
 // representing the first and last addresses in the network.
 //
@@ -95,6 +358,132 @@ func newNetworkFromBoundaries(first, last *IPAddress) (*IPNetwork, error) {
 	}, nil
 }
 
+// SpanningCIDR returns the smallest network that contains every one of the
+// passed networks. An error is returned if no networks are passed or they are
+// not all of the same IP version.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("192.168.1.1/32")
+//	nw2, _ := netaddr.NewIPNetwork("192.168.1.200/32")
+//	span, err := netaddr.SpanningCIDR(nw1, nw2)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(span) // Output: "192.168.1.0/24"
+func SpanningCIDR(nets ...*IPNetwork) (*IPNetwork, error) {
+	if len(nets) == 0 {
+		return nil, fmt.Errorf("at least one network is required to compute a spanning CIDR")
+	}
+
+	version := nets[0].version
+	first, last := nets[0].First(), nets[0].Last()
+	for _, nw := range nets[1:] {
+		if nw.version != version {
+			return nil, fmt.Errorf("version of networks, %s: %d, %s: %d, don't match", nets[0], version.number, nw, nw.version.number)
+		}
+		if nw.First().LessThan(first) {
+			first = nw.First()
+		}
+		if nw.Last().GreaterThan(last) {
+			last = nw.Last()
+		}
+	}
+	return newNetworkFromBoundaries(first, last)
+}
+
+// SpanningCIDRForAddresses returns the smallest network that contains every
+// one of the passed addresses. An error is returned if no addresses are
+// passed, any of them holds no valid address, or they are not all of the same
+// IP version.
+//
+// Example usage:
+//
+//	span, err := netaddr.SpanningCIDRForAddresses(netaddr.NewIP("192.168.1.1"), netaddr.NewIP("192.168.1.200"))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(span) // Output: "192.168.1.0/24"
+func SpanningCIDRForAddresses(addrs ...*IPAddress) (*IPNetwork, error) {
+	nets := make([]*IPNetwork, 0, len(addrs))
+	for i, addr := range addrs {
+		if addr == nil || addr.Version() == nil {
+			return nil, fmt.Errorf("address %d: %w", i, ErrorInvalidAddress)
+		}
+		nets = append(nets, newNetworkFromIP(addr.Version(), addr))
+	}
+	return SpanningCIDR(nets...)
+}
+
+// SmallestMatchingCIDR returns the most specific of cidrs, i.e. the one with
+// the longest prefix length, that contains addr. nil is returned if none of
+// them match.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/16")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.1.0/26")
+//	match := netaddr.SmallestMatchingCIDR(netaddr.NewIP("10.0.1.1"), []*netaddr.IPNetwork{nw1, nw2})
+//	fmt.Println(match) // Output: "10.0.1.0/26"
+func SmallestMatchingCIDR(addr *IPAddress, cidrs []*IPNetwork) *IPNetwork {
+	var match *IPNetwork
+	for _, nw := range cidrs {
+		if nw.version != addr.Version() || !nw.ContainsAddress(addr) {
+			continue
+		}
+		if match == nil || nw.Mask.PrefixLen() > match.Mask.PrefixLen() {
+			match = nw
+		}
+	}
+	return match
+}
+
+// LargestMatchingCIDR returns the least specific of cidrs, i.e. the one with
+// the shortest prefix length, that contains addr. nil is returned if none of
+// them match.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/16")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.1.0/26")
+//	match := netaddr.LargestMatchingCIDR(netaddr.NewIP("10.0.1.1"), []*netaddr.IPNetwork{nw1, nw2})
+//	fmt.Println(match) // Output: "10.0.0.0/16"
+func LargestMatchingCIDR(addr *IPAddress, cidrs []*IPNetwork) *IPNetwork {
+	var match *IPNetwork
+	for _, nw := range cidrs {
+		if nw.version != addr.Version() || !nw.ContainsAddress(addr) {
+			continue
+		}
+		if match == nil || nw.Mask.PrefixLen() < match.Mask.PrefixLen() {
+			match = nw
+		}
+	}
+	return match
+}
+
+// AllMatchingCIDRs returns every one of cidrs that contains addr, sorted from
+// least to most specific. Networks with the same prefix length keep their
+// relative order.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.1.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.0/8")
+//	matches := netaddr.AllMatchingCIDRs(netaddr.NewIP("10.0.1.1"), []*netaddr.IPNetwork{nw1, nw2})
+//	fmt.Println(matches) // Output: [10.0.0.0/8 10.0.1.0/24]
+func AllMatchingCIDRs(addr *IPAddress, cidrs []*IPNetwork) []*IPNetwork {
+	var matches []*IPNetwork
+	for _, nw := range cidrs {
+		if nw.version == addr.Version() && nw.ContainsAddress(addr) {
+			matches = append(matches, nw)
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b *IPNetwork) int {
+		return a.Mask.PrefixLen() - b.Mask.PrefixLen()
+	})
+	return matches
+}
+
 // First returns the first IP address in the network.
 //
 // Example usage:
@@ -103,7 +492,7 @@ func newNetworkFromBoundaries(first, last *IPAddress) (*IPNetwork, error) {
 //	first := nw.First()
 //	fmt.Println(first) // Output: "192.168.1.0"
 func (nw *IPNetwork) First() *IPAddress {
-	return nw.start.ToIPAddress()
+	return nw.start.ToIPAddressV(nw.version)
 }
 
 // Last returns the last IP address in the network.
@@ -117,7 +506,347 @@ func (nw *IPNetwork) Last() *IPAddress {
 	return nw.start.
 		Add(nw.Length()).
 		Sub(NewIPNumber(1)).
-		ToIPAddress()
+		ToIPAddressV(nw.version)
+}
+
+// AddressAt returns the address at the passed zero based index within the
+// network, i.e. First() plus index, without iterating. ErrorAddressOutOFBounds
+// is returned if index is negative or not less than Length().
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	ip, err := nw.AddressAt(netaddr.NewIPNumber(42))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(ip) // Output: "192.168.1.42"
+func (nw *IPNetwork) AddressAt(index *IPNumber) (*IPAddress, error) {
+	if index.Sign() < 0 || index.GreaterThanOrEqual(nw.Length()) {
+		return nil, ErrorAddressOutOFBounds
+	}
+	return nw.start.Add(index).ToIPAddressV(nw.version), nil
+}
+
+// RandomAddress returns an address chosen uniformly at random from the whole
+// network, including any network and broadcast address, using rng as the
+// source of randomness. The same seed yields the same sequence of addresses.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("2001:db8::/32")
+//	rng := rand.New(rand.NewSource(1))
+//	fmt.Println(nw.RandomAddress(rng))
+func (nw *IPNetwork) RandomAddress(rng *rand.Rand) *IPAddress {
+	offset := new(big.Int).Rand(rng, nw.Length().Int)
+	return nw.start.Add(&IPNumber{Int: offset}).ToIPAddressV(nw.version)
+}
+
+// IndexOf returns the zero based index of addr within the network, i.e. its
+// offset from First(). It is the inverse of AddressAt. An error is returned if
+// addr is not within the network.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	index, err := nw.IndexOf(netaddr.NewIP("192.168.1.42"))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(index) // Output: 42
+func (nw *IPNetwork) IndexOf(addr *IPAddress) (*IPNumber, error) {
+	if !nw.ContainsAddress(addr) {
+		return nil, fmt.Errorf("address %s is not within network %s", addr, nw)
+	}
+	return addr.ToInt().Sub(nw.start), nil
+}
+
+// Version returns the IP version of the network.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.Version()) // Output: IPv4
+func (nw *IPNetwork) Version() *Version {
+	return nw.version
+}
+
+// FirstInt returns the integer value of the first address in the network. It
+// implements Addressable.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.FirstInt()) // Output: 3232235776
+func (nw *IPNetwork) FirstInt() *IPNumber {
+	return nw.First().ToInt()
+}
+
+// LastInt returns the integer value of the last address in the network. It
+// implements Addressable.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.LastInt()) // Output: 3232236031
+func (nw *IPNetwork) LastInt() *IPNumber {
+	return nw.Last().ToInt()
+}
+
+// NetworkAddress returns the network address, i.e. the address with all host
+// bits set to zero.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.NetworkAddress()) // Output: "192.168.1.0"
+func (nw *IPNetwork) NetworkAddress() *IPAddress {
+	return nw.First()
+}
+
+// Broadcast returns the broadcast address, i.e. the address with all host
+// bits set to one. IPv6 has no concept of broadcast, so ErrorNoBroadcast is
+// returned for IPv6 networks; use Last() to get the final address instead.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	broadcast, err := nw.Broadcast()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(broadcast) // Output: "192.168.1.255"
+func (nw *IPNetwork) Broadcast() (*IPAddress, error) {
+	if nw.version == IPv6 {
+		return nil, ErrorNoBroadcast
+	}
+	return nw.Last(), nil
+}
+
+// NetmaskAddress returns the network's mask as an address of the network's
+// version, e.g. 255.255.255.0 for a /24.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.NetmaskAddress()) // Output: "255.255.255.0"
+func (nw *IPNetwork) NetmaskAddress() *IPAddress {
+	mask := make(net.IP, len(*nw.Mask.IPMask))
+	copy(mask, *nw.Mask.IPMask)
+	return &IPAddress{
+		IP:      &mask,
+		version: nw.version,
+	}
+}
+
+// WildcardAddress returns the network's hostmask, also known as the wildcard
+// mask, as an address of the network's version, e.g. 0.0.0.255 for a /24.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.WildcardAddress()) // Output: "0.0.0.255"
+func (nw *IPNetwork) WildcardAddress() *IPAddress {
+	wildcard := make(net.IP, len(*nw.Mask.IPMask))
+	for i, b := range *nw.Mask.IPMask {
+		wildcard[i] = ^b
+	}
+	return &IPAddress{
+		IP:      &wildcard,
+		version: nw.version,
+	}
+}
+
+// NetworkSummary describes the addressing properties of a network, as
+// returned by IPNetwork.Summary.
+type NetworkSummary struct {
+	// Network is the first address of the network.
+	Network *IPAddress
+	// Broadcast is the broadcast address, or nil for IPv6 networks and IPv4
+	// networks with a prefix of /31 or longer, which have no broadcast address.
+	Broadcast *IPAddress
+	// FirstUsable and LastUsable bound the addresses assignable to hosts.
+	FirstUsable *IPAddress
+	LastUsable  *IPAddress
+	// HostCount is the number of addresses assignable to hosts.
+	HostCount *IPNumber
+	PrefixLen int
+	Netmask   string
+	Hostmask  string
+}
+
+// Summary returns the addressing properties of the network in one call. For
+// IPv4 networks with a prefix shorter than /31 the network and broadcast
+// addresses are excluded from the usable hosts, as in UsableHosts.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	summary := nw.Summary()
+//	fmt.Println(summary.FirstUsable, summary.LastUsable, summary.HostCount)
+//	// Output: 192.168.1.1 192.168.1.254 254
+func (nw *IPNetwork) Summary() NetworkSummary {
+	first, last := nw.usableBounds()
+	summary := NetworkSummary{
+		Network:     nw.NetworkAddress(),
+		FirstUsable: first.ToIPAddressV(nw.version),
+		LastUsable:  last.ToIPAddressV(nw.version),
+		HostCount:   nw.AssignableCount(),
+		PrefixLen:   nw.Mask.PrefixLen(),
+		Netmask:     nw.Mask.Dotted(),
+		Hostmask:    nw.Mask.Hostmask(),
+	}
+	if nw.version == IPv4 && summary.PrefixLen < 31 {
+		summary.Broadcast = nw.Last()
+	}
+	return summary
+}
+
+// Hosts returns every IP address in the network, from First() to Last()
+// inclusive. For large networks prefer HostsSeq, which does not allocate the
+// whole slice.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/30")
+//	fmt.Println(nw.Hosts()) // Output: [192.168.1.0 192.168.1.1 192.168.1.2 192.168.1.3]
+func (nw *IPNetwork) Hosts() []*IPAddress {
+	return slices.Collect(nw.HostsSeq())
+}
+
+// HostsSeq returns an iterator over every IP address in the network, from
+// First() to Last() inclusive.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/8")
+//	for host := range nw.HostsSeq() {
+//	    fmt.Println(host)
+//	}
+func (nw *IPNetwork) HostsSeq() iter.Seq[*IPAddress] {
+	return addressSeq(nw.start, nw.Last().ToInt())
+}
+
+// UsableHosts returns the IP addresses in the network that can be assigned to
+// hosts. For IPv4 networks with a prefix shorter than /31 the network and
+// broadcast addresses are excluded, otherwise every address is returned. For
+// large networks prefer UsableHostsSeq, which does not allocate the whole
+// slice.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/30")
+//	fmt.Println(nw.UsableHosts()) // Output: [192.168.1.1 192.168.1.2]
+func (nw *IPNetwork) UsableHosts() []*IPAddress {
+	return slices.Collect(nw.UsableHostsSeq())
+}
+
+// UsableHostsSeq returns an iterator over the IP addresses in the network
+// that can be assigned to hosts, as returned by UsableHosts, e.g. to lease
+// them from a DHCP pool.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/8")
+//	for host := range nw.UsableHostsSeq() {
+//	    fmt.Println(host)
+//	}
+func (nw *IPNetwork) UsableHostsSeq() iter.Seq[*IPAddress] {
+	first, last := nw.usableBounds()
+	return addressSeq(first, last)
+}
+
+// AssignableCount returns the number of addresses in the network that can be
+// assigned to hosts, unlike Length which returns the raw size. For IPv4
+// networks with a prefix shorter than /31 the network and broadcast addresses
+// are reserved and not counted. IPv4 /31 and /32 networks, and all IPv6
+// networks, have every address assignable.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw.AssignableCount()) // Output: 254
+func (nw *IPNetwork) AssignableCount() *IPNumber {
+	first, last := nw.usableBounds()
+	return last.Sub(first).Add(NewIPNumber(1))
+}
+
+// usableBounds returns the integer values of the first and last usable host
+// addresses in the network. Every calculation of usable hosts goes through
+// here: IPv4 /31 point-to-point links have both addresses usable (RFC 3021),
+// a /32 has its single address usable, and IPv6 networks never lose a network
+// or broadcast address.
+func (nw *IPNetwork) usableBounds() (*IPNumber, *IPNumber) {
+	first := nw.start
+	last := nw.Last().ToInt()
+	if nw.version == IPv4 && nw.PrefixLength().LessThan(NewIPNumber(31)) {
+		first = first.Add(NewIPNumber(1))
+		last = last.Sub(NewIPNumber(1))
+	}
+	return first, last
+}
+
+// AddressInts returns an iterator over the integer value of every IP address
+// in the network, from First() to Last() inclusive, without building the
+// addresses themselves.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/30")
+//	for num := range nw.AddressInts() {
+//	    fmt.Println(num) // Output: 3232235776, 3232235777, ...
+//	}
+func (nw *IPNetwork) AddressInts() iter.Seq[*IPNumber] {
+	return numberSeq(nw.start, nw.Last().ToInt())
+}
+
+// addressSeq returns an iterator over the IP addresses from first to last
+// inclusive.
+func addressSeq(first, last *IPNumber) iter.Seq[*IPAddress] {
+	if a, ok := first.ipv4Value(); ok {
+		if b, ok := last.ipv4Value(); ok {
+			return ipv4Seq(a, b, newIPv4Address)
+		}
+	}
+	return func(yield func(*IPAddress) bool) {
+		for num := range numberSeq(first, last) {
+			if !yield(num.ToIPAddress()) {
+				return
+			}
+		}
+	}
+}
+
+// numberSeq returns an iterator over the IPNumbers from first to last
+// inclusive.
+func numberSeq(first, last *IPNumber) iter.Seq[*IPNumber] {
+	if a, ok := first.ipv4Value(); ok {
+		if b, ok := last.ipv4Value(); ok {
+			return ipv4Seq(a, b, newIPv4Number)
+		}
+	}
+	return func(yield func(*IPNumber) bool) {
+		for num := first; num.LessThanOrEqual(last); num = num.Add(NewIPNumber(1)) {
+			if !yield(num) {
+				return
+			}
+		}
+	}
+}
+
+// ipv4Seq returns an iterator over build(v) for every IPv4 integer value v
+// from first to last inclusive, counting in uint32 rather than IPNumbers.
+func ipv4Seq[T any](first, last uint32, build func(uint32) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if first > last {
+			return
+		}
+		for v := first; ; v++ {
+			if !yield(build(v)) || v == last {
+				return
+			}
+		}
+	}
 }
 
 // IPMask represents a subnet mask.
@@ -125,6 +854,39 @@ type IPMask struct {
 	*net.IPMask
 }
 
+// NewMaskFromHostmask returns a new IPMask from a hostmask, also known as a
+// wildcard mask, in address form, e.g. "0.0.0.255". An error is returned if
+// the hostmask is not the bitwise inverse of a contiguous mask.
+//
+// Example usage:
+//
+//	mask, err := netaddr.NewMaskFromHostmask("0.0.0.255")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(mask) // Output: "/24"
+func NewMaskFromHostmask(hostmask string) (*IPMask, error) {
+	ip := net.ParseIP(hostmask)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid hostmask: %q", hostmask)
+	}
+	// Only narrow dotted-decimal input, since an IPv6 hostmask such as that of
+	// a /80 would otherwise be mistaken for an IPv4-mapped address.
+	if !strings.Contains(hostmask, ":") {
+		ip = ip.To4()
+	}
+
+	mask := make(net.IPMask, len(ip))
+	for i, b := range ip {
+		mask[i] = ^b
+	}
+	ones, bits := mask.Size()
+	if bits == 0 {
+		return nil, fmt.Errorf("hostmask %s is not the inverse of a contiguous mask", hostmask)
+	}
+	return NewMask(int64(ones), int64(bits)), nil
+}
+
 // Equals compares two IPMasks and returns true if they are equal.
 //
 // Example usage:
@@ -151,7 +913,63 @@ func (m *IPMask) LessThan(other *IPMask) bool {
 	return maskInt.Cmp(otherInt) == -1
 }
 
-// MergeCIDRs merges a slice of IPNetwork objects into an IPSet.
+// String returns the prefix length form of the mask, e.g. "/24".
+//
+// Example usage:
+//
+//	mask := netaddr.NewMask(24, 32)
+//	fmt.Println(mask.String()) // Output: "/24"
+func (m *IPMask) String() string {
+	return fmt.Sprintf("/%d", m.PrefixLen())
+}
+
+// Dotted returns the mask in address form, i.e. dotted-decimal for IPv4 masks
+// such as "255.255.255.0", and the IPv6 address notation for IPv6 masks.
+//
+// Example usage:
+//
+//	mask := netaddr.NewMask(24, 32)
+//	fmt.Println(mask.Dotted()) // Output: "255.255.255.0"
+func (m *IPMask) Dotted() string {
+	return net.IP(*m.IPMask).String()
+}
+
+// Hostmask returns the hostmask, also known as the wildcard mask, in address
+// form. The hostmask is the bitwise inverse of the mask, e.g. "0.0.0.255" for
+// a /24.
+//
+// Example usage:
+//
+//	mask := netaddr.NewMask(24, 32)
+//	fmt.Println(mask.Hostmask()) // Output: "0.0.0.255"
+func (m *IPMask) Hostmask() string {
+	hostmask := make(net.IP, len(*m.IPMask))
+	for i, b := range *m.IPMask {
+		hostmask[i] = ^b
+	}
+	if len(hostmask) == IPv6len {
+		// net.IP would render e.g. the hostmask of a /80 as an IPv4 address,
+		// whereas netip keeps the IPv6 notation.
+		return netip.AddrFrom16([IPv6len]byte(hostmask)).String()
+	}
+	return hostmask.String()
+}
+
+// PrefixLen returns the number of leading ones in the mask.
+//
+// Example usage:
+//
+//	mask := netaddr.NewMask(24, 32)
+//	fmt.Println(mask.PrefixLen()) // Output: 24
+func (m *IPMask) PrefixLen() int {
+	ones, _ := m.Size()
+	return ones
+}
+
+// MergeCIDRs merges a slice of IPNetwork objects into an IPSet. Overlapping and
+// adjacent networks are combined and re-expanded into the minimal CIDRs
+// covering them, see CidrMerge. The result is in canonical order, so the same
+// networks passed in any order produce the same IPSet.
 //
 // Example usage:
 //
@@ -160,48 +978,59 @@ func (m *IPMask) LessThan(other *IPMask) bool {
 //	merged := netaddr.MergeCIDRs([]netaddr.IPNetwork{*cidr1, *cidr2})
 //	fmt.Println(merged)
 func MergeCIDRs(cidrs []IPNetwork) IPSet {
-	var (
-		merged IPSet
-		ranges []IPRange
-	)
+	networks := make([]*IPNetwork, 0, len(cidrs))
+	for i := range cidrs {
+		networks = append(networks, &cidrs[i])
+	}
+	return CidrMerge(networks...)
+}
 
-	for _, cidr := range cidrs {
-		ranges = append(ranges, IPRange{
-			version: cidr.version,
-			first:   cidr.First(),
-			last:    cidr.Last(),
-			network: &cidr})
-	}
-
-	sort.Sort(ByIPRanges(ranges))
-
-	for i := len(ranges) - 1; i > 0; i-- {
-		current := ranges[i]
-		next := ranges[i-1]
-		if current.version == next.version &&
-			current.first.ToInt().LessThan(next.last.ToInt()) {
-			ranges[i-1] = struct {
-				version *Version
-				first   *IPAddress
-				last    *IPAddress
-				network *IPNetwork
-			}{version: current.version, first: current.last, last: MinAddress(next.first, current.first), network: &IPNetwork{}}
-		}
+// CidrMerge merges the passed networks into the minimal list of CIDRs covering
+// exactly the same addresses. Duplicate, contained and adjacent networks are
+// combined, and the result is sorted as by IPNetwork.LessThan, i.e. IPv4
+// networks before IPv6 networks and then by address.
+//
+// Example usage:
+//
+//	cidr1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	cidr2, _ := netaddr.NewIPNetwork("10.0.1.0/24")
+//	merged := netaddr.CidrMerge(cidr1, cidr2)
+//	fmt.Println(merged) // Output: [10.0.0.0/23]
+func CidrMerge(networks ...*IPNetwork) []*IPNetwork {
+	var merged []*IPNetwork
+	for _, r := range IPSet(networks).ToRanges() {
+		// The ranges are built from valid networks of a single version, so
+		// converting them back to CIDRs cannot fail.
+		cidrs, _ := r.ToCIDRs()
+		merged = append(merged, cidrs...)
 	}
+	return merged
+}
 
-	for _, value := range ranges {
-		if value.network == nil {
-			merged = append(merged, value.network)
-		} else {
-			subnets, err := IPRangeToCIDRS(value.version, value.first, value.last)
-			if err != nil {
-				// do something
-			}
-			merged = append(merged, subnets...)
+// Collapse removes duplicates and any network contained in another from the
+// passed networks, sorted as by IPNetwork.Compare. Unlike CidrMerge, adjacent
+// networks are not merged, so the prefix boundaries of the remaining networks
+// are preserved.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.0/25")
+//	nw3, _ := netaddr.NewIPNetwork("10.0.1.0/24")
+//	fmt.Println(netaddr.Collapse([]*netaddr.IPNetwork{nw1, nw2, nw3})) // Output: [10.0.0.0/24 10.0.1.0/24]
+func Collapse(nets []*IPNetwork) []*IPNetwork {
+	sorted := slices.SortedFunc(slices.Values(nets), (*IPNetwork).Compare)
+
+	var collapsed []*IPNetwork
+	for _, nw := range sorted {
+		// CIDRs are either nested or disjoint, and a supernet sorts before
+		// its subnets, so only the last kept network can contain nw.
+		if len(collapsed) > 0 && collapsed[len(collapsed)-1].IsSupernetOf(nw) {
+			continue
 		}
+		collapsed = append(collapsed, nw)
 	}
-
-	return merged
+	return collapsed
 }
 
 // Partition defines a structure to hold the parts of an IP network before, during, and after partitioning.
@@ -211,8 +1040,38 @@ type Partition struct {
 	After     []*IPNetwork
 }
 
+// All returns the networks of the partition in address order: those before
+// the excluded network, the excluded network itself, then those after it.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	exclude, _ := netaddr.NewIPNetwork("192.168.1.128/25")
+//	fmt.Println(nw.Partition(exclude).All()) // Output: [192.168.1.0/25 192.168.1.128/25]
+func (p *Partition) All() []*IPNetwork {
+	all := append([]*IPNetwork{}, p.Before...)
+	if p.Partition != nil {
+		all = append(all, p.Partition)
+	}
+	return append(all, p.After...)
+}
+
+// String returns a representation of the partition for debugging, listing
+// the networks before, at and after the excluded network.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	exclude, _ := netaddr.NewIPNetwork("192.168.1.128/25")
+//	fmt.Println(nw.Partition(exclude))
+//	// Output: before=[192.168.1.0/25] partition=192.168.1.128/25 after=[]
+func (p *Partition) String() string {
+	return fmt.Sprintf("before=%v partition=%v after=%v", p.Before, p.Partition, p.After)
+}
+
 // Partition divides the IPNetwork into three parts: the portion before the exclude network,
 // the partition that overlaps with the exclude network, and the portion after.
+// An empty Partition is returned if the networks are of different IP versions.
 //
 // Example usage:
 //
@@ -221,6 +1080,9 @@ type Partition struct {
 //	partition := nw.Partition(exclude)
 //	fmt.Println(partition)
 func (nw *IPNetwork) Partition(exclude *IPNetwork) *Partition {
+	if nw.version != exclude.version {
+		return &Partition{}
+	}
 
 	if exclude.Last().LessThan(nw.First()) {
 		// Exclude subnet's upper bound address less than target
@@ -297,7 +1159,63 @@ func (nw *IPNetwork) Partition(exclude *IPNetwork) *Partition {
 	}
 }
 
+// Exclude returns the minimal list of CIDRs covering the network, but not any
+// address of other. If other does not overlap the network, the network is
+// returned unchanged, and if other covers it entirely an empty list is
+// returned. An error is returned if the networks are of different IP versions.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	exclude, _ := netaddr.NewIPNetwork("10.0.0.64/26")
+//	remainder, err := nw.Exclude(exclude)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(remainder) // Output: [10.0.0.0/26 10.0.0.128/25]
+func (nw *IPNetwork) Exclude(other *IPNetwork) ([]*IPNetwork, error) {
+	if nw.version != other.version {
+		return nil, fmt.Errorf("version of networks, %s: %d, %s: %d, don't match", nw, nw.version.number, other, other.version.number)
+	}
+	if !nw.Overlaps(other) {
+		return []*IPNetwork{nw}, nil
+	}
+
+	partition := nw.Partition(other)
+	remainder := append([]*IPNetwork{}, partition.Before...)
+	return append(remainder, partition.After...), nil
+}
+
+// FreeSpace returns the minimal list of CIDRs within the network that are not
+// covered by any member of allocated, sorted as by IPSet.Networks. An error is
+// returned if a member of allocated is not entirely within the network, as
+// that usually means the allocations were recorded against the wrong
+// supernet.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	used1, _ := netaddr.NewIPNetwork("10.0.0.0/26")
+//	used2, _ := netaddr.NewIPNetwork("10.0.0.128/26")
+//	free, err := nw.FreeSpace(netaddr.IPSet{used1, used2})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(free) // Output: "10.0.0.64/26,10.0.0.192/26"
+func (nw *IPNetwork) FreeSpace(allocated IPSet) (IPSet, error) {
+	free := IPSet{nw}
+	for _, member := range allocated {
+		if !nw.IsSupernetOf(member) {
+			return nil, fmt.Errorf("allocated network %s is not within %s", member, nw)
+		}
+		free.Remove(member)
+	}
+	return free.Networks(), nil
+}
+
 // Subnet divides a network into smaller subnets based on the provided CIDR prefix.
+// For large numbers of subnets prefer IterateSubnets, which does not allocate
+// the whole slice.
 //
 // Example usage:
 //
@@ -311,27 +1229,234 @@ func (nw *IPNetwork) Partition(exclude *IPNetwork) *Partition {
 //	}
 func (nw *IPNetwork) Subnet(newCIDRPrefix int) ([]*IPNetwork, error) {
 	thisCidrPrefix, addressBits := nw.Mask.Size()
-	if !(0 <= thisCidrPrefix || thisCidrPrefix <= addressBits) {
-		return nil, fmt.Errorf("prefix %d is not valid", thisCidrPrefix)
+	if newCIDRPrefix > addressBits {
+		return nil, fmt.Errorf("prefix %d is not valid", newCIDRPrefix)
 	}
 
 	if thisCidrPrefix > newCIDRPrefix {
 		return []*IPNetwork{}, nil
 	}
-	maxNoSubnets := int(math.Pow(2, float64(addressBits-thisCidrPrefix)) / math.Pow(2, float64(addressBits-newCIDRPrefix)))
-	var results []*IPNetwork
-	for i := 0; i < maxNoSubnets; i++ {
-		newCIDR := fmt.Sprintf("%s/%d", nw.First().IP, newCIDRPrefix)
-		newSubnet, err := NewIPNetwork(newCIDR)
-		if err != nil {
-			return nil, err
+	return slices.Collect(nw.IterateSubnets(newCIDRPrefix)), nil
+}
+
+// IterateSubnets returns an iterator over the subnets of the network with the
+// provided CIDR prefix, in ascending order. Nothing is yielded if the prefix
+// is shorter than the network's own prefix or longer than the address length.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/8")
+//	for subnet := range nw.IterateSubnets(24) {
+//	    fmt.Println(subnet)
+//	}
+func (nw *IPNetwork) IterateSubnets(newCIDRPrefix int) iter.Seq[*IPNetwork] {
+	return func(yield func(*IPNetwork) bool) {
+		thisCidrPrefix, addressBits := nw.Mask.Size()
+		if thisCidrPrefix > newCIDRPrefix || newCIDRPrefix > addressBits {
+			return
+		}
+
+		mask := NewMask(int64(newCIDRPrefix), int64(addressBits))
+		step := mask.Length()
+		last := nw.Last().ToInt()
+		for start := nw.start; start.LessThanOrEqual(last); start = start.Add(step) {
+			subnet := &IPNetwork{
+				start:   start,
+				version: nw.version,
+				Mask:    mask,
+			}
+			if !yield(subnet) {
+				return
+			}
+		}
+	}
+}
+
+// Walk calls fn for each subnet of the network with the provided CIDR prefix,
+// in ascending order, as yielded by IterateSubnets. Walking stops at the first
+// error returned by fn, which is returned as is. As with Subnet, an error is
+// returned if the prefix is longer than the address length, and nothing is
+// walked if it is shorter than the network's own prefix.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	err := nw.Walk(26, func(subnet *netaddr.IPNetwork) error {
+//	    fmt.Println(subnet)
+//	    return nil
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+func (nw *IPNetwork) Walk(prefix int, fn func(*IPNetwork) error) error {
+	if _, addressBits := nw.Mask.Size(); prefix > addressBits {
+		return fmt.Errorf("prefix %d is not valid", prefix)
+	}
+	for subnet := range nw.IterateSubnets(prefix) {
+		if err := fn(subnet); err != nil {
+			return err
 		}
-		sL := newSubnet.Length()
-		sL.Mul(sL.Int, big.NewInt(int64(i)))
-		newSubnet.start = newSubnet.start.Add(sL)
-		results = append(results, newSubnet)
 	}
-	return results, nil
+	return nil
+}
+
+// SplitIntoSubnets divides the network into n equally sized subnets. An error is
+// returned if n is not a power of two or the network is too small to be split
+// n ways.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	subnets, err := nw.SplitIntoSubnets(4)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(subnets) // Output: [192.168.1.0/26 192.168.1.64/26 192.168.1.128/26 192.168.1.192/26]
+func (nw *IPNetwork) SplitIntoSubnets(n int) ([]*IPNetwork, error) {
+	if n <= 0 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("number of subnets %d is not a power of two", n)
+	}
+	ones, addressBits := nw.Mask.Size()
+	newCIDRPrefix := ones + bits.TrailingZeros(uint(n))
+	if newCIDRPrefix > addressBits {
+		return nil, fmt.Errorf("network %s cannot be split into %d subnets", nw, n)
+	}
+	return nw.Subnet(newCIDRPrefix)
+}
+
+// Supernet returns the network with the shorter prefix length, prefixLen, that
+// contains this network. An error is returned if prefixLen is negative or
+// longer than the network's own prefix length.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.128/25")
+//	supernet, err := nw.Supernet(24)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(supernet) // Output: "192.168.1.0/24"
+func (nw *IPNetwork) Supernet(prefixLen int) (*IPNetwork, error) {
+	ones, bits := nw.Mask.Size()
+	if prefixLen < 0 || prefixLen > ones {
+		return nil, fmt.Errorf("prefix %d is not valid for a supernet of %s", prefixLen, nw)
+	}
+
+	// The below represents start &= -(1 << (bits - prefixLen))
+	start := nw.start.And(NewIPNumber(1).Lsh(uint(bits - prefixLen)).Neg())
+	return &IPNetwork{
+		start:   start,
+		version: nw.version,
+		Mask:    NewMask(int64(prefixLen), int64(bits)),
+	}, nil
+}
+
+// Parent returns the immediate supernet of the network, i.e. the network with
+// a prefix length one shorter that contains it.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.128/25")
+//	parent, err := nw.Parent()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(parent) // Output: "192.168.1.0/24"
+func (nw *IPNetwork) Parent() (*IPNetwork, error) {
+	ones, _ := nw.Mask.Size()
+	return nw.Supernet(ones - 1)
+}
+
+// Grow returns the network whose prefix is bits shorter than this one's and
+// which contains it, e.g. growing 10.0.0.0/24 by 2 gives 10.0.0.0/22. An error
+// is returned if bits is negative or the prefix would be shorter than /0.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.1.0/24")
+//	grown, err := nw.Grow(2)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(grown) // Output: "10.0.0.0/22"
+func (nw *IPNetwork) Grow(bits int) (*IPNetwork, error) {
+	if bits < 0 {
+		return nil, fmt.Errorf("cannot grow %s by %d bits", nw, bits)
+	}
+	return nw.Supernet(nw.Mask.PrefixLen() - bits)
+}
+
+// Shrink returns the network whose prefix is bits longer than this one's and
+// which starts at the same address, i.e. its first subnet of that size. An
+// error is returned if bits is negative or the prefix would exceed the bit
+// length of the network's IP version.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/22")
+//	shrunk, err := nw.Shrink(2)
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(shrunk) // Output: "10.0.0.0/24"
+func (nw *IPNetwork) Shrink(bits int) (*IPNetwork, error) {
+	ones, addressBits := nw.Mask.Size()
+	if bits < 0 || ones+bits > addressBits {
+		return nil, fmt.Errorf("cannot shrink %s by %d bits", nw, bits)
+	}
+	return &IPNetwork{
+		start:   nw.start,
+		version: nw.version,
+		Mask:    NewMask(int64(ones+bits), int64(addressBits)),
+	}, nil
+}
+
+// NextSubnet returns the network of the same size immediately following this
+// one. ErrorAddressOutOFBounds is returned if it would extend beyond the
+// address space of the network's IP version.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	next, err := nw.NextSubnet()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(next) // Output: "10.0.1.0/24"
+func (nw *IPNetwork) NextSubnet() (*IPNetwork, error) {
+	start := nw.start.Add(nw.Length())
+	if start.GreaterThan(nw.version.max) {
+		return nil, ErrorAddressOutOFBounds
+	}
+	return &IPNetwork{
+		start:   start,
+		version: nw.version,
+		Mask:    nw.Mask,
+	}, nil
+}
+
+// PreviousSubnet returns the network of the same size immediately preceding
+// this one. ErrorAddressOutOFBounds is returned if it would extend below the
+// start of the address space of the network's IP version.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.1.0/24")
+//	previous, err := nw.PreviousSubnet()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(previous) // Output: "10.0.0.0/24"
+func (nw *IPNetwork) PreviousSubnet() (*IPNetwork, error) {
+	start := nw.start.Sub(nw.Length())
+	if start.LessThan(NewIPNumber(0)) {
+		return nil, ErrorAddressOutOFBounds
+	}
+	return &IPNetwork{
+		start:   start,
+		version: nw.version,
+		Mask:    nw.Mask,
+	}, nil
 }
 
 // reverse reverses the order of the slice of IPNetwork pointers.
@@ -400,8 +1525,7 @@ func IPRangeToCIDRS(version *Version, start, end *IPAddress) ([]*IPNetwork, erro
 	}
 
 	if subnet.First().LessThan(start) {
-		excludeAddress := start
-		_, err := excludeAddress.Increment(NewIPNumber(-1))
+		excludeAddress, err := start.Increment(NewIPNumber(-1))
 		if err != nil {
 			return nil, err
 		}
@@ -418,8 +1542,7 @@ func IPRangeToCIDRS(version *Version, start, end *IPAddress) ([]*IPNetwork, erro
 	}
 
 	if subnet.Last().GreaterThan(end) {
-		excludeAddress := end
-		excludeAddress, err := excludeAddress.Increment(NewIPNumber(1))
+		excludeAddress, err := end.Increment(NewIPNumber(1))
 		if err != nil && err != ErrorAddressOutOFBounds {
 			return nil, err
 		}
@@ -433,38 +1556,17 @@ func IPRangeToCIDRS(version *Version, start, end *IPAddress) ([]*IPNetwork, erro
 	return cidrs, nil
 }
 
-// IPSet represents an unordered collection of unique IP addresses and subnets.
-// IPAddresses are represented here as IPNetworks with a mask of /32
-type IPSet []*IPNetwork
-
-// Remove removes an IP address or subnet from this IPSet. Does nothing if it is not already a member.
-//
-// Example usage:
-//
-//	set := netaddr.IPSet{nw1, nw2}
-//	set.Remove(nw1)
-//	fmt.Println(set)
-func (set *IPSet) Remove() {}
-
-// Add adds an IP address or IP network to this IPSet.
-// IP addresses are represented as IPNetworks with a /32 subnet mask, and where possible,
-// the IP addresses and IPNetworks are merged with other members of the set to form more concise CIDR blocks.
-//
-// Example usage:
-//
-//	set := netaddr.IPSet{}
-//	set.Add(nw1)
-//	fmt.Println(set)
-func (set *IPSet) Add() {}
-
-// Pop removes an arbitrary subnet from this IPSet.
+// Contains checks if the network contains a specific IP address. It is an
+// alias for ContainsAddress; use ContainsSubnetwork to check for a network.
 //
 // Example usage:
 //
-//	set := netaddr.IPSet{nw1, nw2}
-//	set.Pop()
-//	fmt.Println(set)
-func (set *IPSet) Pop() {}
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	ip := netaddr.NewIP("192.168.1.100")
+//	fmt.Println(nw.Contains(ip)) // Output: true
+func (nw *IPNetwork) Contains(addr *IPAddress) bool {
+	return nw.ContainsAddress(addr)
+}
 
 // ContainsAddress checks if the network contains a specific IP address.
 //
@@ -489,6 +1591,74 @@ func (nw *IPNetwork) ContainsSubnetwork(other *IPNetwork) bool {
 		nw.Last().GreaterThanOrEqual(other.Last())
 }
 
+// IsSupernetOf returns true when nw contains every address of other. The check
+// is non-strict, so a network is a supernet of itself. Networks of different
+// IP versions are never supernets of each other.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("192.168.1.128/25")
+//	fmt.Println(nw1.IsSupernetOf(nw2)) // Output: true
+func (nw *IPNetwork) IsSupernetOf(other *IPNetwork) bool {
+	return nw.version == other.version && nw.ContainsSubnetwork(other)
+}
+
+// IsSubnetOf returns true when every address of nw is contained by other. The
+// check is non-strict, so a network is a subnet of itself. Networks of
+// different IP versions are never subnets of each other.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("192.168.1.128/25")
+//	nw2, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	fmt.Println(nw1.IsSubnetOf(nw2)) // Output: true
+func (nw *IPNetwork) IsSubnetOf(other *IPNetwork) bool {
+	return other.IsSupernetOf(nw)
+}
+
+// Overlaps returns true when nw and other share at least one address. Networks
+// of different IP versions never overlap.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.128/25")
+//	fmt.Println(nw1.Overlaps(nw2)) // Output: true
+func (nw *IPNetwork) Overlaps(other *IPNetwork) bool {
+	return nw.version == other.version &&
+		nw.First().LessThanOrEqual(other.Last()) &&
+		other.First().LessThanOrEqual(nw.Last())
+}
+
+// Intersection returns the range of addresses shared by nw and other, and
+// whether they overlap at all. Networks of different IP versions never
+// overlap. As CIDRs are either nested or disjoint, the range always spans the
+// smaller of the two networks when they overlap.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.128/25")
+//	overlap, ok := nw1.Intersection(nw2)
+//	text, _ := overlap.MarshalText()
+//	fmt.Println(string(text), ok) // Output: 10.0.0.128-10.0.0.255 true
+func (nw *IPNetwork) Intersection(other *IPNetwork) (*IPRange, bool) {
+	if !nw.Overlaps(other) {
+		return nil, false
+	}
+	first, last := nw.First(), nw.Last()
+	if otherFirst := other.First(); otherFirst.GreaterThan(first) {
+		first = otherFirst
+	}
+	if otherLast := other.Last(); otherLast.LessThan(last) {
+		last = otherLast
+	}
+	// The networks overlap, so first <= last and the versions match.
+	r, err := newIPRange(first, last)
+	return r, err == nil
+}
+
 // Length returns the number of valid IP addresses in a subnet.
 //
 // Example usage:
@@ -529,6 +1699,7 @@ func (nw *IPNetwork) Equal(other *IPNetwork) bool {
 }
 
 // LessThan compares two IPNetworks, returning true if nw is less than other.
+// Networks are ordered as described by Compare.
 //
 // Example usage:
 //
@@ -536,14 +1707,53 @@ func (nw *IPNetwork) Equal(other *IPNetwork) bool {
 //	nw2, _ := netaddr.NewIPNetwork("192.168.2.0/24")
 //	fmt.Println(nw1.LessThan(nw2)) // Output: true
 func (nw *IPNetwork) LessThan(other *IPNetwork) bool {
+	return nw.Compare(other) < 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether nw sorts before, equal to
+// or after other. IPv4 networks sort before IPv6 networks, then networks are
+// ordered by their first address, and networks starting at the same address
+// by prefix length, so a supernet sorts before its first subnet.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("192.168.1.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("192.168.1.0/25")
+//	fmt.Println(nw1.Compare(nw2)) // Output: -1
+func (nw *IPNetwork) Compare(other *IPNetwork) int {
 	if nw.version != other.version {
-		return nw.version.LessThan(other.version)
-	}
-	if !nw.start.Equal(other.start) {
-		return nw.start.LessThan(other.start)
+		if nw.version.LessThan(other.version) {
+			return -1
+		}
+		return 1
 	}
-	if !nw.Mask.Equals(other.Mask) {
-		return nw.Mask.LessThan(other.Mask)
+	if c := nw.start.cmp(other.start); c != 0 {
+		return c
 	}
-	return false
+	return cmp.Compare(nw.Mask.PrefixLen(), other.Mask.PrefixLen())
+}
+
+// ByNetworks is a type that implements sort.Interface for sorting a slice of
+// IPNetworks into the canonical order described by IPNetwork.Compare.
+//
+// Example usage:
+//
+//	networks, _ := nw.Subnet(26)
+//	sort.Sort(netaddr.ByNetworks(networks))
+type ByNetworks []*IPNetwork
+
+// Len returns the number of networks in the slice. It is required by sort.Interface.
+func (ns ByNetworks) Len() int {
+	return len(ns)
+}
+
+// Less reports whether the network at index i should sort before the network
+// at index j. It is required by sort.Interface.
+func (ns ByNetworks) Less(i, j int) bool {
+	return ns[i].Compare(ns[j]) < 0
+}
+
+// Swap exchanges the networks at indices i and j. It is required by sort.Interface.
+func (ns ByNetworks) Swap(i, j int) {
+	ns[i], ns[j] = ns[j], ns[i]
 }
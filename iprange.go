@@ -1,5 +1,12 @@
 package netaddr
 
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
 // IPRange represents a range of IP addresses. It includes the IP version (IPv4 or IPv6),
 // the first and last IP addresses in the range, and the network to which the range belongs.
 type IPRange struct {
@@ -9,6 +16,106 @@ type IPRange struct {
 	network *IPNetwork
 }
 
+// newIPRange returns the IPRange from first to last, including the smallest
+// network spanning them. An error is returned if the addresses are of
+// different IP versions or first is greater than last.
+func newIPRange(first, last *IPAddress) (*IPRange, error) {
+	network, err := newNetworkFromBoundaries(first, last)
+	if err != nil {
+		return nil, err
+	}
+	if first.GreaterThan(last) {
+		return nil, fmt.Errorf("first address %s is greater than last address %s", first, last)
+	}
+	return &IPRange{
+		version: first.Version(),
+		first:   first,
+		last:    last,
+		network: network,
+	}, nil
+}
+
+// NewIPRangeFromCIDR returns the IPRange spanning all addresses of the passed
+// CIDR, from its network address to its last address. An error is returned if
+// the CIDR is invalid.
+//
+// Example usage:
+//
+//	ipRange, err := netaddr.NewIPRangeFromCIDR("192.168.1.0/24")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	text, _ := ipRange.MarshalText()
+//	fmt.Println(string(text)) // Output: "192.168.1.0-192.168.1.255"
+func NewIPRangeFromCIDR(cidr string) (*IPRange, error) {
+	nw, err := NewIPNetwork(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return newIPRange(nw.First(), nw.Last())
+}
+
+// RangeFromCIDRs returns the single IPRange spanning the passed networks, in
+// any order. Overlapping networks are allowed, but an error is returned if
+// nets is empty, mixes IP versions or leaves a gap between networks.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/25")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.128/25")
+//	ipRange, err := netaddr.RangeFromCIDRs([]*netaddr.IPNetwork{nw1, nw2})
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	text, _ := ipRange.MarshalText()
+//	fmt.Println(string(text)) // Output: "10.0.0.0-10.0.0.255"
+func RangeFromCIDRs(nets []*IPNetwork) (*IPRange, error) {
+	ranges := IPSet(nets).ToRanges()
+	switch {
+	case len(ranges) == 0:
+		return nil, fmt.Errorf("no networks to build an ip range from")
+	case len(ranges) > 1 && ranges[0].version != ranges[len(ranges)-1].version:
+		return nil, fmt.Errorf("networks are of different IP versions")
+	case len(ranges) > 1:
+		return nil, fmt.Errorf("networks are not contiguous: gap between %s and %s", ranges[0].last, ranges[1].first)
+	}
+	return &ranges[0], nil
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the range as its
+// first and last addresses separated by a hyphen, e.g. "10.0.0.1-10.0.0.254".
+//
+// Example usage:
+//
+//	data, _ := json.Marshal(ipRange)
+//	fmt.Println(string(data)) // Output: "\"10.0.0.1-10.0.0.254\""
+func (r *IPRange) MarshalText() ([]byte, error) {
+	return []byte(r.first.String() + "-" + r.last.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing a range in the
+// "first-last" format. An error is returned if either address is invalid, the
+// addresses are of different IP versions or first is greater than last.
+//
+// Example usage:
+//
+//	var ipRange netaddr.IPRange
+//	if err := json.Unmarshal([]byte(`"10.0.0.1-10.0.0.254"`), &ipRange); err != nil {
+//	    fmt.Println(err)
+//	}
+func (r *IPRange) UnmarshalText(text []byte) error {
+	first, last, found := strings.Cut(string(text), "-")
+	if !found || net.ParseIP(first) == nil || net.ParseIP(last) == nil {
+		return fmt.Errorf("invalid ip range: %q", text)
+	}
+	parsed, err := newIPRange(NewIP(first), NewIP(last))
+	if err != nil {
+		return err
+	}
+	*r = *parsed
+	return nil
+}
+
 // ByIPRanges is a type that implements sort.Interface for sorting a slice of IPRange.
 // It sorts the IP ranges first by version (IPv4 or IPv6), then by the starting IP address,
 // then by the ending IP address, and finally by the network if the previous criteria are equal.
@@ -63,3 +170,170 @@ func (rs ByIPRanges) Swap(i, j int) {
 	rs[i] = rs[j]
 	rs[j] = ith
 }
+
+// Version returns the IP version of the range.
+//
+// Example usage:
+//
+//	ipRange, _ := netaddr.NewIPRangeFromCIDR("192.168.1.0/24")
+//	fmt.Println(ipRange.Version()) // Output: IPv4
+func (r *IPRange) Version() *Version {
+	return r.version
+}
+
+// FirstInt returns the integer value of the first address in the range. It
+// implements Addressable.
+//
+// Example usage:
+//
+//	ipRange, _ := netaddr.NewIPRangeFromCIDR("192.168.1.0/24")
+//	fmt.Println(ipRange.FirstInt()) // Output: 3232235776
+func (r *IPRange) FirstInt() *IPNumber {
+	return r.first.ToInt()
+}
+
+// LastInt returns the integer value of the last address in the range. It
+// implements Addressable.
+//
+// Example usage:
+//
+//	ipRange, _ := netaddr.NewIPRangeFromCIDR("192.168.1.0/24")
+//	fmt.Println(ipRange.LastInt()) // Output: 3232236031
+func (r *IPRange) LastInt() *IPNumber {
+	return r.last.ToInt()
+}
+
+// ToCIDRs converts the IPRange to the minimal list of CIDR blocks covering it,
+// using the range's own version and boundary addresses.
+//
+// Example usage:
+//
+//	cidrs, err := ipRange.ToCIDRs()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	for _, cidr := range cidrs {
+//	    fmt.Println(cidr)
+//	}
+func (r *IPRange) ToCIDRs() ([]*IPNetwork, error) {
+	return IPRangeToCIDRS(r.version, r.first, r.last)
+}
+
+// ToIPSet converts the IPRange to an IPSet of the minimal list of CIDR blocks
+// covering it, as returned by ToCIDRs. An empty set is returned if the range's
+// boundaries are invalid, e.g. of different IP versions.
+//
+// Example usage:
+//
+//	set := ipRange.ToIPSet()
+//	fmt.Println(set.Size())
+func (r *IPRange) ToIPSet() IPSet {
+	cidrs, err := r.ToCIDRs()
+	if err != nil {
+		return IPSet{}
+	}
+	return IPSet(cidrs)
+}
+
+// SplitAt splits the range at addr, returning the range from the first
+// address up to the address before addr, and the range from addr to the last
+// address. If addr is the first address of the range the left side is empty
+// and returned as nil. An error is returned if addr is outside the range.
+//
+// Example usage:
+//
+//	ipRange, _ := netaddr.NewIPRangeFromCIDR("10.0.0.0/24")
+//	left, right, err := ipRange.SplitAt(netaddr.NewIP("10.0.0.100"))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	l, _ := left.MarshalText()
+//	r, _ := right.MarshalText()
+//	fmt.Println(string(l), string(r)) // Output: 10.0.0.0-10.0.0.99 10.0.0.100-10.0.0.255
+func (r *IPRange) SplitAt(addr *IPAddress) (*IPRange, *IPRange, error) {
+	if addr.Version() != r.version || addr.LessThan(r.first) || addr.GreaterThan(r.last) {
+		return nil, nil, fmt.Errorf("address %s is outside of the ip range %s-%s", addr, r.first, r.last)
+	}
+
+	right, err := newIPRange(addr, r.last)
+	if err != nil {
+		return nil, nil, err
+	}
+	if addr.Equal(r.first) {
+		return nil, right, nil
+	}
+	// addr is greater than first, so the address before it exists.
+	previous, _ := addr.Sub(NewIPNumber(1))
+	left, err := newIPRange(r.first, previous)
+	if err != nil {
+		return nil, nil, err
+	}
+	return left, right, nil
+}
+
+// Overlaps returns true when the range shares at least one address with other.
+// Ranges of different IP versions never overlap.
+//
+// Example usage:
+//
+//	fmt.Println(range1.Overlaps(range2))
+func (r *IPRange) Overlaps(other *IPRange) bool {
+	return r.version == other.version &&
+		r.first.LessThanOrEqual(other.last) &&
+		other.first.LessThanOrEqual(r.last)
+}
+
+// Adjacent returns true when other starts at the address immediately after
+// the end of the range, or ends at the address immediately before its start,
+// so that together they form one contiguous range. Ranges of different IP
+// versions are never adjacent.
+//
+// Example usage:
+//
+//	fmt.Println(range1.Adjacent(range2))
+func (r *IPRange) Adjacent(other *IPRange) bool {
+	if r.version != other.version {
+		return false
+	}
+	one := NewIPNumber(1)
+	return other.first.ToInt().Equal(r.last.ToInt().Add(one)) ||
+		r.first.ToInt().Equal(other.last.ToInt().Add(one))
+}
+
+// MergeRanges sorts ranges with ByIPRanges and coalesces any of the same
+// version that overlap or are adjacent, returning the minimal list of IPRanges
+// covering them. It is the range-level analogue of CidrMerge.
+//
+// Example usage:
+//
+//	merged := netaddr.MergeRanges([]netaddr.IPRange{range1, range2, range3})
+//	for _, r := range merged {
+//	    text, _ := r.MarshalText()
+//	    fmt.Println(string(text))
+//	}
+func MergeRanges(ranges []IPRange) []IPRange {
+	sorted := make([]IPRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Sort(ByIPRanges(sorted))
+
+	var merged []IPRange
+	for _, r := range sorted {
+		if len(merged) > 0 {
+			previous := &merged[len(merged)-1]
+			if previous.Overlaps(&r) || previous.Adjacent(&r) {
+				if r.last.GreaterThan(previous.last) {
+					previous.last = r.last
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+
+	for i := range merged {
+		// Both boundaries come from ranges of the same version, so the
+		// spanning network can always be computed.
+		merged[i].network, _ = newNetworkFromBoundaries(merged[i].first, merged[i].last)
+	}
+	return merged
+}
@@ -1,6 +1,7 @@
 package netaddr
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"testing"
@@ -87,3 +88,208 @@ func TestByIPRangesSwap(t *testing.T) {
 	ranges.Swap(0, 1)
 	assert.Equal(t, expectedRanges, ranges)
 }
+
+func TestIPRangeToCIDRsMethod(t *testing.T) {
+	t.Parallel()
+
+	r := IPRange{IPv4, NewIP("10.0.0.0"), NewIP("10.0.2.255"), nil}
+	cidrs, err := r.ToCIDRs()
+	assert.NoError(t, err)
+	assert.Equal(t, []*IPNetwork{newTestNetwork(t, "10.0.0.0/23"), newTestNetwork(t, "10.0.2.0/24")}, cidrs)
+}
+
+func TestIPRangeMarshalText(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name string
+		r    IPRange
+		json string
+	}{
+		{"ipv4", IPRange{IPv4, NewIP("10.0.0.1"), NewIP("10.0.0.254"), cidrIpv41}, `"10.0.0.1-10.0.0.254"`},
+		{"ipv6", ipv6Range3, `"2001:db8::ff00:42:8328-2001:db8::ff00:42:8329"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(&test.r)
+			assert.NoError(t, err)
+			assert.Equal(t, test.json, string(data))
+
+			var result IPRange
+			assert.NoError(t, json.Unmarshal(data, &result))
+			assert.True(t, test.r.first.Equal(result.first))
+			assert.True(t, test.r.last.Equal(result.last))
+			assert.Equal(t, test.r.version, result.version)
+		})
+	}
+}
+
+func TestIPRangeUnmarshalTextInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{
+		"10.0.0.1",
+		"10.0.0.1-",
+		"10.0.0.1-not an address",
+		"10.0.0.1-2001:db8::1",
+		"10.0.0.254-10.0.0.1",
+	} {
+		var result IPRange
+		assert.Error(t, result.UnmarshalText([]byte(text)), text)
+	}
+}
+
+func TestNewIPRangeFromCIDR(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewIPRangeFromCIDR("192.168.1.0/24")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.1.0", r.first.String())
+	assert.Equal(t, "192.168.1.255", r.last.String())
+	assert.Equal(t, IPv4, r.version)
+	assert.Equal(t, "192.168.1.0/24", r.network.String())
+
+	_, err = NewIPRangeFromCIDR("192.168.1.0/33")
+	assert.Error(t, err)
+}
+
+func TestRangeFromCIDRs(t *testing.T) {
+	t.Parallel()
+
+	low := newTestNetwork(t, "10.0.0.0/25")
+	high := newTestNetwork(t, "10.0.0.128/25")
+
+	// Order of the networks does not matter.
+	for _, nets := range [][]*IPNetwork{{low, high}, {high, low}} {
+		r, err := RangeFromCIDRs(nets)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.0", r.first.String())
+		assert.Equal(t, "10.0.0.255", r.last.String())
+		assert.Equal(t, "10.0.0.0/24", r.network.String())
+	}
+
+	for _, nets := range [][]*IPNetwork{
+		nil,
+		{low, newTestNetwork(t, "10.0.1.0/25")},
+		{low, newTestNetwork(t, "2001:db8::/64")},
+	} {
+		_, err := RangeFromCIDRs(nets)
+		assert.Error(t, err, "%v", nets)
+	}
+}
+
+func TestIPRangeSplitAt(t *testing.T) {
+	t.Parallel()
+
+	r, err := newIPRange(NewIP("10.0.0.10"), NewIP("10.0.0.20"))
+	assert.NoError(t, err)
+
+	var tests = []struct {
+		name  string
+		addr  *IPAddress
+		left  string
+		right string
+	}{
+		{"mid-range", NewIP("10.0.0.15"), "10.0.0.10-10.0.0.14", "10.0.0.15-10.0.0.20"},
+		{"last address", NewIP("10.0.0.20"), "10.0.0.10-10.0.0.19", "10.0.0.20-10.0.0.20"},
+		{"first address", NewIP("10.0.0.10"), "", "10.0.0.10-10.0.0.20"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			left, right, err := r.SplitAt(test.addr)
+			assert.NoError(t, err)
+			if test.left == "" {
+				assert.Nil(t, left)
+			} else {
+				text, _ := left.MarshalText()
+				assert.Equal(t, test.left, string(text))
+			}
+			text, _ := right.MarshalText()
+			assert.Equal(t, test.right, string(text))
+		})
+	}
+
+	for _, addr := range []*IPAddress{NewIP("10.0.0.9"), NewIP("10.0.0.21"), NewIP("::a00:f"), NewIP("invalid")} {
+		_, _, err := r.SplitAt(addr)
+		assert.Error(t, err, "%s", addr)
+	}
+}
+
+func TestIPRangeOverlapsAndAdjacent(t *testing.T) {
+	t.Parallel()
+
+	newRange := func(first, last string) *IPRange {
+		r, err := newIPRange(NewIP(first), NewIP(last))
+		assert.NoError(t, err)
+		return r
+	}
+
+	var tests = []struct {
+		name     string
+		r        *IPRange
+		other    *IPRange
+		overlaps bool
+		adjacent bool
+	}{
+		{"adjacent", newRange("10.0.0.0", "10.0.0.9"), newRange("10.0.0.10", "10.0.0.20"), false, true},
+		{"adjacent reversed", newRange("10.0.0.10", "10.0.0.20"), newRange("10.0.0.0", "10.0.0.9"), false, true},
+		{"overlapping", newRange("10.0.0.0", "10.0.0.10"), newRange("10.0.0.10", "10.0.0.20"), true, false},
+		{"contained", newRange("10.0.0.0", "10.0.0.20"), newRange("10.0.0.5", "10.0.0.6"), true, false},
+		{"disjoint", newRange("10.0.0.0", "10.0.0.9"), newRange("10.0.0.11", "10.0.0.20"), false, false},
+		{"mixed versions", newRange("0.0.0.0", "0.0.0.9"), newRange("::a", "::14"), false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.overlaps, test.r.Overlaps(test.other))
+			assert.Equal(t, test.adjacent, test.r.Adjacent(test.other))
+		})
+	}
+}
+
+func TestMergeRanges(t *testing.T) {
+	t.Parallel()
+
+	newRange := func(first, last string) IPRange {
+		r, err := newIPRange(NewIP(first), NewIP(last))
+		assert.NoError(t, err)
+		return *r
+	}
+
+	var tests = []struct {
+		name     string
+		ranges   []IPRange
+		expected []string
+	}{
+		{
+			"overlapping ipv4 ranges",
+			[]IPRange{newRange("10.0.0.50", "10.0.0.200"), newRange("10.0.0.0", "10.0.0.100"), newRange("10.0.0.150", "10.0.0.254")},
+			[]string{"10.0.0.0-10.0.0.254"},
+		},
+		{
+			"adjacent ranges",
+			[]IPRange{newRange("10.0.0.10", "10.0.0.20"), newRange("10.0.0.0", "10.0.0.9")},
+			[]string{"10.0.0.0-10.0.0.20"},
+		},
+		{
+			"disjoint and mixed versions",
+			[]IPRange{newRange("2001:db8::1", "2001:db8::2"), newRange("10.0.0.11", "10.0.0.20"), newRange("10.0.0.0", "10.0.0.9")},
+			[]string{"10.0.0.0-10.0.0.9", "10.0.0.11-10.0.0.20", "2001:db8::1-2001:db8::2"},
+		},
+		{"empty", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var merged []string
+			for _, r := range MergeRanges(test.ranges) {
+				text, err := r.MarshalText()
+				assert.NoError(t, err)
+				merged = append(merged, string(text))
+			}
+			assert.Equal(t, test.expected, merged)
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package netaddr
+
+import (
+	"encoding/json"
+	"iter"
+	"sort"
+	"strings"
+)
+
+// IPSet represents an unordered collection of unique IP addresses and subnets.
+// IPAddresses are represented here as IPNetworks with a mask of /32
+type IPSet []*IPNetwork
+
+// Remove removes the addresses of an IP network from this IPSet, splitting any
+// member that only partially overlaps it. Does nothing if none of its
+// addresses are members.
+//
+// Example usage:
+//
+//	set := netaddr.IPSet{nw1, nw2}
+//	set.Remove(nw1)
+//	fmt.Println(set)
+func (set *IPSet) Remove(nw *IPNetwork) {
+	var remaining IPSet
+	for _, member := range *set {
+		if member.version != nw.version {
+			remaining = append(remaining, member)
+			continue
+		}
+		// The versions match, so Exclude cannot fail.
+		remainder, _ := member.Exclude(nw)
+		remaining = append(remaining, remainder...)
+	}
+	*set = remaining
+}
+
+// Add adds an IP network to this IPSet.
+// IP addresses are represented as IPNetworks with a /32 subnet mask, and where possible,
+// the IP addresses and IPNetworks are merged with other members of the set to form more concise CIDR blocks.
+//
+// Example usage:
+//
+//	set := netaddr.IPSet{}
+//	set.Add(nw1)
+//	fmt.Println(set)
+func (set *IPSet) Add(nw *IPNetwork) {
+	*set = CidrMerge(append(*set, nw)...)
+}
+
+// AddRange adds the addresses of an IP range to this IPSet, as the minimal
+// list of CIDR blocks covering it, merged with the other members as by Add.
+//
+// Example usage:
+//
+//	ipRange, _ := netaddr.NewIPRangeFromCIDR("10.0.0.0/25")
+//	set := netaddr.IPSet{}
+//	set.AddRange(ipRange)
+//	fmt.Println(set) // Output: "10.0.0.0/25"
+func (set *IPSet) AddRange(r *IPRange) {
+	*set = CidrMerge(append(*set, r.ToIPSet()...)...)
+}
+
+// RemoveRange removes the addresses of an IP range from this IPSet, splitting
+// any member that only partially overlaps it, as by Remove.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	ipRange, _ := netaddr.NewIPRangeFromCIDR("10.0.0.0/25")
+//	set := netaddr.IPSet{nw}
+//	set.RemoveRange(ipRange)
+//	fmt.Println(set) // Output: "10.0.0.128/25"
+func (set *IPSet) RemoveRange(r *IPRange) {
+	for _, nw := range r.ToIPSet() {
+		set.Remove(nw)
+	}
+}
+
+// Pop removes an arbitrary subnet from this IPSet and returns it. nil is
+// returned if the set is empty.
+//
+// Example usage:
+//
+//	set := netaddr.IPSet{nw1, nw2}
+//	nw := set.Pop()
+//	fmt.Println(nw, set)
+func (set *IPSet) Pop() *IPNetwork {
+	s := *set
+	if len(s) == 0 {
+		return nil
+	}
+	nw := s[len(s)-1]
+	*set = s[:len(s)-1]
+	return nw
+}
+
+// Contains returns true when addr is within any member of this IPSet.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	set := netaddr.IPSet{nw}
+//	fmt.Println(set.Contains(netaddr.NewIP("10.0.0.1"))) // Output: true
+func (set IPSet) Contains(addr *IPAddress) bool {
+	return addr.inAnyOf(set)
+}
+
+// Networks returns a copy of the members of this IPSet, sorted with IPv4
+// networks before IPv6 networks and then by address.
+//
+// Example usage:
+//
+//	set := netaddr.IPSet{nw1, nw2}
+//	for _, nw := range set.Networks() {
+//	    fmt.Println(nw)
+//	}
+func (set IPSet) Networks() []*IPNetwork {
+	networks := append([]*IPNetwork{}, set...)
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].LessThan(networks[j])
+	})
+	return networks
+}
+
+// String returns the CIDRs of the members of this IPSet joined by commas, in
+// the order of Networks, so the output is stable regardless of the order the
+// members were added in.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.2.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	set := netaddr.IPSet{nw1, nw2}
+//	fmt.Println(set) // Output: "10.0.0.0/24,10.0.2.0/24"
+func (set IPSet) String() string {
+	cidrs := make([]string, 0, len(set))
+	for _, nw := range set.Networks() {
+		cidrs = append(cidrs, nw.String())
+	}
+	return strings.Join(cidrs, ",")
+}
+
+// Addresses returns an iterator over every address of every member of this
+// IPSet, in the order of Networks. Addresses are produced lazily, so large
+// sets can be iterated without allocating them all up front.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/30")
+//	set := netaddr.IPSet{nw}
+//	for ip := range set.Addresses() {
+//	    fmt.Println(ip)
+//	}
+func (set IPSet) Addresses() iter.Seq[*IPAddress] {
+	return func(yield func(*IPAddress) bool) {
+		for _, nw := range set.Networks() {
+			for ip := range nw.HostsSeq() {
+				if !yield(ip) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Size returns the total number of addresses in this IPSet, summing the
+// length of each member. Members are assumed to be disjoint, as they are when
+// the set is built with Add; overlapping members are counted more than once.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.1.0/24")
+//	set := netaddr.IPSet{nw1, nw2}
+//	fmt.Println(set.Size()) // Output: 512
+func (set IPSet) Size() *IPNumber {
+	size := NewIPNumber(0)
+	for _, nw := range set {
+		size = size.Add(nw.Length())
+	}
+	return size
+}
+
+// MarshalJSON implements json.Marshaler, encoding the set as an array of the
+// CIDR strings of its members, e.g. ["10.0.0.0/24","10.0.1.0/24"]. Members are
+// written in the order of Networks, so equal sets encode identically however
+// they were built.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	data, _ := json.Marshal(netaddr.IPSet{nw})
+//	fmt.Println(string(data)) // Output: ["10.0.0.0/24"]
+func (set IPSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.Networks())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, parsing an array of CIDR strings.
+// The networks are merged with CidrMerge, so the result is normalised.
+//
+// Example usage:
+//
+//	var set netaddr.IPSet
+//	if err := json.Unmarshal([]byte(`["10.0.0.0/24","10.0.1.0/24"]`), &set); err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(set) // Output: [10.0.0.0/23]
+func (set *IPSet) UnmarshalJSON(data []byte) error {
+	var networks []*IPNetwork
+	if err := json.Unmarshal(data, &networks); err != nil {
+		return err
+	}
+
+	valid := networks[:0]
+	for _, nw := range networks {
+		if nw != nil {
+			valid = append(valid, nw)
+		}
+	}
+	// Merge once rather than calling Add per network, which would merge the
+	// whole set again for every element.
+	*set = append(IPSet{}, CidrMerge(valid...)...)
+	return nil
+}
+
+// ToRanges returns the members of this IPSet as the minimal list of IPRanges
+// covering them, collapsing contiguous members into a single range.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.1.0/25")
+//	set := netaddr.IPSet{nw1, nw2}
+//	fmt.Println(len(set.ToRanges())) // Output: 1
+func (set IPSet) ToRanges() []IPRange {
+	ranges := make([]IPRange, 0, len(set))
+	for _, nw := range set {
+		ranges = append(ranges, IPRange{
+			version: nw.version,
+			first:   nw.First(),
+			last:    nw.Last(),
+			network: nw,
+		})
+	}
+	return MergeRanges(ranges)
+}
+
+// IsSubsetOf returns true when every address of this IPSet is also in other.
+// The sets are compared by coverage, so how their members are split into
+// CIDRs does not matter. An empty set is a subset of every set.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/25")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	fmt.Println(netaddr.IPSet{nw1}.IsSubsetOf(netaddr.IPSet{nw2})) // Output: true
+func (set IPSet) IsSubsetOf(other IPSet) bool {
+	// Merged ranges are maximal, so each range of set must lie within a
+	// single range of other.
+	otherRanges := other.ToRanges()
+	for _, r := range set.ToRanges() {
+		contained := false
+		for _, o := range otherRanges {
+			if r.version == o.version && o.first.LessThanOrEqual(r.first) && r.last.LessThanOrEqual(o.last) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf returns true when every address of other is also in this
+// IPSet, see IsSubsetOf.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.0/25")
+//	fmt.Println(netaddr.IPSet{nw1}.IsSupersetOf(netaddr.IPSet{nw2})) // Output: true
+func (set IPSet) IsSupersetOf(other IPSet) bool {
+	return other.IsSubsetOf(set)
+}
+
+// Equal returns true when this IPSet and other contain exactly the same
+// addresses, regardless of the order of their members or how they are split
+// into CIDRs.
+//
+// Example usage:
+//
+//	nw1, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	nw2, _ := netaddr.NewIPNetwork("10.0.0.0/25")
+//	nw3, _ := netaddr.NewIPNetwork("10.0.0.128/25")
+//	fmt.Println(netaddr.IPSet{nw1}.Equal(netaddr.IPSet{nw3, nw2})) // Output: true
+func (set IPSet) Equal(other IPSet) bool {
+	ranges, otherRanges := set.ToRanges(), other.ToRanges()
+	if len(ranges) != len(otherRanges) {
+		return false
+	}
+	for i := range ranges {
+		if !ranges[i].first.Equal(otherRanges[i].first) || !ranges[i].last.Equal(otherRanges[i].last) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,272 @@
+package netaddr
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPSetAdd(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		set      IPSet
+		add      *IPNetwork
+		expected []string
+	}{
+		{"empty set", IPSet{}, newTestNetwork(t, "10.0.0.0/24"), []string{"10.0.0.0/24"}},
+		{"adjacent network", IPSet{newTestNetwork(t, "10.0.0.0/24")}, newTestNetwork(t, "10.0.1.0/24"), []string{"10.0.0.0/23"}},
+		{"contained network", IPSet{newTestNetwork(t, "10.0.0.0/24")}, newTestNetwork(t, "10.0.0.128/25"), []string{"10.0.0.0/24"}},
+		{"disjoint network", IPSet{newTestNetwork(t, "10.0.2.0/24")}, newTestNetwork(t, "10.0.0.0/24"), []string{"10.0.0.0/24", "10.0.2.0/24"}},
+		{"mixed versions", IPSet{newTestNetwork(t, "2001:db8::/32")}, newTestNetwork(t, "10.0.0.0/24"), []string{"10.0.0.0/24", "2001:db8::/32"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.set.Add(test.add)
+			assert.Equal(t, test.expected, networkStrings(test.set))
+		})
+	}
+}
+
+func TestIPSetRemove(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		set      IPSet
+		remove   *IPNetwork
+		expected []string
+	}{
+		{"whole member", IPSet{newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "10.0.2.0/24")}, newTestNetwork(t, "10.0.0.0/24"), []string{"10.0.2.0/24"}},
+		{"part of member", IPSet{newTestNetwork(t, "10.0.0.0/24")}, newTestNetwork(t, "10.0.0.64/26"), []string{"10.0.0.0/26", "10.0.0.128/25"}},
+		{"not a member", IPSet{newTestNetwork(t, "10.0.0.0/24")}, newTestNetwork(t, "10.0.1.0/24"), []string{"10.0.0.0/24"}},
+		{"other version", IPSet{newTestNetwork(t, "10.0.0.0/24")}, newTestNetwork(t, "::/0"), []string{"10.0.0.0/24"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.set.Remove(test.remove)
+			assert.Equal(t, test.expected, networkStrings(test.set))
+		})
+	}
+}
+
+func TestIPSetAddAndRemoveRange(t *testing.T) {
+	t.Parallel()
+
+	r, err := newIPRange(NewIP("10.0.0.1"), NewIP("10.0.0.6"))
+	assert.NoError(t, err)
+
+	set := IPSet{}
+	set.AddRange(r)
+	assert.Equal(t, []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"}, networkStrings(set))
+
+	// Filling the gaps merges the range with the other members.
+	set.Add(newTestNetwork(t, "10.0.0.0/32"))
+	set.Add(newTestNetwork(t, "10.0.0.7/32"))
+	assert.Equal(t, []string{"10.0.0.0/29"}, networkStrings(set))
+
+	set.RemoveRange(r)
+	assert.Equal(t, []string{"10.0.0.0/32", "10.0.0.7/32"}, networkStrings(set))
+}
+
+func TestIPSetContains(t *testing.T) {
+	t.Parallel()
+
+	set := IPSet{newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "2001:db8::/32")}
+	assert.True(t, set.Contains(NewIP("10.0.0.0")))
+	assert.True(t, set.Contains(NewIP("10.0.0.255")))
+	assert.True(t, set.Contains(NewIP("2001:db8::1")))
+	assert.False(t, set.Contains(NewIP("10.0.1.0")))
+	assert.False(t, set.Contains(NewIP("::a00:1")))
+	assert.False(t, IPSet{}.Contains(NewIP("10.0.0.1")))
+}
+
+func TestIPSetPop(t *testing.T) {
+	t.Parallel()
+
+	set := IPSet{newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "10.0.2.0/24")}
+	assert.Equal(t, "10.0.2.0/24", set.Pop().String())
+	assert.Equal(t, "10.0.0.0/24", set.Pop().String())
+	assert.Nil(t, set.Pop())
+	assert.Empty(t, set)
+}
+
+func TestIPSetNetworks(t *testing.T) {
+	t.Parallel()
+
+	set := IPSet{
+		newTestNetwork(t, "2001:db8::/32"),
+		newTestNetwork(t, "10.0.2.0/24"),
+		newTestNetwork(t, "10.0.0.0/24"),
+	}
+	networks := set.Networks()
+	assert.Equal(t, []string{"10.0.0.0/24", "10.0.2.0/24", "2001:db8::/32"}, networkStrings(networks))
+
+	// The returned slice is a copy, so the set keeps its own order.
+	assert.Equal(t, "2001:db8::/32", set[0].String())
+}
+
+func TestIPSetString(t *testing.T) {
+	t.Parallel()
+
+	set := IPSet{newTestNetwork(t, "10.0.2.0/24"), newTestNetwork(t, "10.0.0.0/24")}
+	assert.Equal(t, "10.0.0.0/24,10.0.2.0/24", set.String())
+	assert.Equal(t, "10.0.0.0/24,10.0.2.0/24", fmt.Sprint(set))
+
+	reversed := IPSet{set[1], set[0]}
+	assert.Equal(t, set.String(), reversed.String())
+
+	assert.Equal(t, "", IPSet{}.String())
+}
+
+func TestIPSetAddresses(t *testing.T) {
+	t.Parallel()
+
+	set := IPSet{newTestNetwork(t, "10.0.0.0/30")}
+	var addresses []string
+	for ip := range set.Addresses() {
+		addresses = append(addresses, ip.String())
+	}
+	assert.Equal(t, []string{"10.0.0.0", "10.0.0.1", "10.0.0.2", "10.0.0.3"}, addresses)
+
+	// Stopping early must not walk the rest of a large set.
+	large := IPSet{newTestNetwork(t, "2001:db8::/32")}
+	for ip := range large.Addresses() {
+		assert.Equal(t, "2001:db8::", ip.String())
+		break
+	}
+}
+
+func TestIPSetSize(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		set      IPSet
+		expected *IPNumber
+	}{
+		{"empty set", IPSet{}, NewIPNumber(0)},
+		{"two networks", IPSet{newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "10.0.1.0/24")}, NewIPNumber(512)},
+		{"mixed versions", IPSet{newTestNetwork(t, "10.0.0.0/32"), newTestNetwork(t, "2001:db8::/120")}, NewIPNumber(257)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.True(t, test.expected.Equal(test.set.Size()), "expected %v, got %v", test.expected, test.set.Size())
+		})
+	}
+}
+
+func TestIPSetJSON(t *testing.T) {
+	t.Parallel()
+
+	set := IPSet{newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "2001:db8::/32")}
+	data, err := json.Marshal(set)
+	assert.NoError(t, err)
+	assert.Equal(t, `["10.0.0.0/24","2001:db8::/32"]`, string(data))
+
+	var result IPSet
+	assert.NoError(t, json.Unmarshal(data, &result))
+	assert.Equal(t, networkStrings(set), networkStrings(result))
+
+	// Members are written sorted, whatever order the set was built in.
+	reversed := IPSet{newTestNetwork(t, "2001:db8::/32"), newTestNetwork(t, "10.0.0.0/24")}
+	reversedData, err := json.Marshal(reversed)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(reversedData))
+
+	data, err = json.Marshal(IPSet(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+
+	// Loaded sets are normalised through CidrMerge.
+	assert.NoError(t, json.Unmarshal([]byte(`["10.0.1.0/24","10.0.0.0/24","10.0.0.128/25"]`), &result))
+	assert.Equal(t, []string{"10.0.0.0/23"}, networkStrings(result))
+
+	hosts := make([]string, 0, 1024)
+	for host := range newTestNetwork(t, "10.4.0.0/22").HostsSeq() {
+		hosts = append(hosts, host.String()+"/32")
+	}
+	data, err = json.Marshal(hosts)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &result))
+	assert.Equal(t, []string{"10.4.0.0/22"}, networkStrings(result))
+
+	assert.Error(t, json.Unmarshal([]byte(`["not a network"]`), &result))
+	assert.Error(t, json.Unmarshal([]byte(`"10.0.0.0/24"`), &result))
+}
+
+func TestIPSetToRangesAndBack(t *testing.T) {
+	t.Parallel()
+
+	r, err := newIPRange(NewIP("10.0.0.5"), NewIP("10.0.1.130"))
+	assert.NoError(t, err)
+
+	set := r.ToIPSet()
+	assert.Greater(t, len(set), 1)
+	assert.True(t, NewIPNumber(382).Equal(set.Size()), "size %v", set.Size())
+
+	ranges := set.ToRanges()
+	assert.Len(t, ranges, 1)
+	text, err := ranges[0].MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.5-10.0.1.130", string(text))
+
+	// Contiguous members collapse into one range regardless of order.
+	set = IPSet{newTestNetwork(t, "10.0.2.0/24"), newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "10.0.1.0/24")}
+	ranges = set.ToRanges()
+	assert.Len(t, ranges, 1)
+	assert.Equal(t, "10.0.0.0", ranges[0].first.String())
+	assert.Equal(t, "10.0.2.255", ranges[0].last.String())
+
+	// Members that aren't contiguous stay as separate ranges.
+	set = IPSet{newTestNetwork(t, "10.0.0.0/24"), newTestNetwork(t, "10.0.2.0/24")}
+	assert.Len(t, set.ToRanges(), 2)
+}
+
+func networkStrings(networks []*IPNetwork) []string {
+	strs := make([]string, 0, len(networks))
+	for _, nw := range networks {
+		strs = append(strs, nw.String())
+	}
+	return strs
+}
+
+func TestIPSetRelations(t *testing.T) {
+	t.Parallel()
+
+	whole := IPSet{newTestNetwork(t, "10.0.0.0/24")}
+	halves := IPSet{newTestNetwork(t, "10.0.0.128/25"), newTestNetwork(t, "10.0.0.0/25")}
+	half := IPSet{newTestNetwork(t, "10.0.0.0/25")}
+	mixed := IPSet{newTestNetwork(t, "2001:db8::/32"), newTestNetwork(t, "10.0.0.0/23")}
+	v6 := IPSet{newTestNetwork(t, "::a00:0/120")}
+
+	var tests = []struct {
+		name     string
+		set      IPSet
+		other    IPSet
+		subset   bool
+		superset bool
+	}{
+		{"split equal", whole, halves, true, true},
+		{"proper subset", half, whole, true, false},
+		{"proper superset", whole, half, false, true},
+		{"subset across versions", whole, mixed, true, false},
+		{"same value other version", whole, v6, false, false},
+		{"empty", IPSet{}, whole, true, false},
+		{"both empty", nil, IPSet{}, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.subset, test.set.IsSubsetOf(test.other), "IsSubsetOf")
+			assert.Equal(t, test.superset, test.set.IsSupersetOf(test.other), "IsSupersetOf")
+			assert.Equal(t, test.subset && test.superset, test.set.Equal(test.other), "Equal")
+		})
+	}
+}
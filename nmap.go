@@ -0,0 +1,116 @@
+package netaddr
+
+import (
+	"fmt"
+	"iter"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseNmapRange expands an nmap-style IPv4 target specification, e.g.
+// "10.0.0.1-10" or "10.0.0-2.1,3", into the full list of addresses it covers.
+// Each octet is a comma separated list of numbers and ranges; a range may omit
+// its lower or upper bound, which then defaults to 0 or 255 respectively. For
+// specifications covering many addresses prefer NmapRangeSeq.
+//
+// Example usage:
+//
+//	addrs, err := netaddr.ParseNmapRange("10.0.0.1-3")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(addrs) // Output: [10.0.0.1 10.0.0.2 10.0.0.3]
+func ParseNmapRange(spec string) ([]*IPAddress, error) {
+	seq, err := NmapRangeSeq(spec)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Collect(seq), nil
+}
+
+// NmapRangeSeq validates an nmap-style IPv4 target specification, as accepted
+// by ParseNmapRange, and returns an iterator over the addresses it covers in
+// ascending order.
+//
+// Example usage:
+//
+//	addrs, err := netaddr.NmapRangeSeq("10.0-255.0-255.1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	for addr := range addrs {
+//	    fmt.Println(addr)
+//	}
+func NmapRangeSeq(spec string) (iter.Seq[*IPAddress], error) {
+	octets := strings.Split(spec, ".")
+	if len(octets) != IPv4len {
+		return nil, fmt.Errorf("invalid nmap range %q: expected %d octets", spec, IPv4len)
+	}
+
+	values := make([][]byte, IPv4len)
+	for i, octet := range octets {
+		v, err := parseNmapOctet(spec, octet)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return func(yield func(*IPAddress) bool) {
+		for _, a := range values[0] {
+			for _, b := range values[1] {
+				for _, c := range values[2] {
+					for _, d := range values[3] {
+						ip := net.IP{a, b, c, d}
+						if !yield(&IPAddress{IP: &ip, version: IPv4}) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}, nil
+}
+
+// parseNmapOctet returns the sorted, distinct values matched by a single
+// octet of an nmap target specification.
+func parseNmapOctet(spec, octet string) ([]byte, error) {
+	var matched [256]bool
+	for _, element := range strings.Split(octet, ",") {
+		lower, upper, isRange := strings.Cut(element, "-")
+		if !isRange {
+			upper = lower
+		}
+		if lower == "" && isRange {
+			lower = "0"
+		}
+		if upper == "" && isRange {
+			upper = "255"
+		}
+
+		lo, err := strconv.ParseUint(lower, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nmap range %q: %q is not a number between 0 and 255", spec, lower)
+		}
+		hi, err := strconv.ParseUint(upper, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nmap range %q: %q is not a number between 0 and 255", spec, upper)
+		}
+		if lo > hi {
+			return nil, fmt.Errorf("invalid nmap range %q: range %q is reversed", spec, element)
+		}
+		for v := lo; v <= hi; v++ {
+			matched[v] = true
+		}
+	}
+
+	var values []byte
+	for v, ok := range matched {
+		if ok {
+			values = append(values, byte(v))
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,57 @@
+package netaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNmapRange(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		spec     string
+		expected []*IPAddress
+		wantErr  bool
+	}{
+		{"10.0.0.1-3", []*IPAddress{NewIP("10.0.0.1"), NewIP("10.0.0.2"), NewIP("10.0.0.3")}, false},
+		{"10.0.0-1.1,3", []*IPAddress{
+			NewIP("10.0.0.1"), NewIP("10.0.0.3"), NewIP("10.0.1.1"), NewIP("10.0.1.3"),
+		}, false},
+		{"10.0.0.3,1-2,2", []*IPAddress{NewIP("10.0.0.1"), NewIP("10.0.0.2"), NewIP("10.0.0.3")}, false},
+		{"10.0.0.254-", []*IPAddress{NewIP("10.0.0.254"), NewIP("10.0.0.255")}, false},
+		{"10.0.0.-1", []*IPAddress{NewIP("10.0.0.0"), NewIP("10.0.0.1")}, false},
+		{"192.168.1.1", []*IPAddress{NewIP("192.168.1.1")}, false},
+		{"10.0.0.300", nil, true},
+		{"10.0.0.3-1", nil, true},
+		{"10.0.0", nil, true},
+		{"10.0.0.a", nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			result, err := ParseNmapRange(test.spec)
+			if (err != nil) != test.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, test.wantErr)
+				return
+			}
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestNmapRangeSeq(t *testing.T) {
+	t.Parallel()
+
+	seq, err := NmapRangeSeq("10.0-255.0-255.0-255")
+	assert.NoError(t, err)
+
+	var result []*IPAddress
+	for addr := range seq {
+		result = append(result, addr)
+		if len(result) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []*IPAddress{NewIP("10.0.0.0"), NewIP("10.0.0.1")}, result)
+}
@@ -0,0 +1,124 @@
+package netaddr
+
+import "fmt"
+
+var (
+	// ErrorNoFreeAddress is an error returned when a Pool has no unreserved
+	// address left.
+	ErrorNoFreeAddress = fmt.Errorf("no free address in pool")
+)
+
+// Pool hands out single addresses from the usable hosts of a network, as
+// returned by IPNetwork.UsableHosts, tracking which are reserved. For IPv4
+// networks shorter than /31 the network and broadcast addresses are never
+// handed out. Use an Allocator to hand out subnets instead.
+type Pool struct {
+	network *IPNetwork
+	usable  *IPNumber
+	free    IPSet
+}
+
+// NewPool returns a Pool handing out the usable hosts of nw, with none
+// reserved.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/29")
+//	pool := netaddr.NewPool(nw)
+func NewPool(nw *IPNetwork) *Pool {
+	first, last := nw.usableBounds()
+	// The bounds are of the network's version and first <= last, so the
+	// range is always valid.
+	usable, _ := newIPRange(first.ToIPAddressV(nw.version), last.ToIPAddressV(nw.version))
+	return &Pool{
+		network: nw,
+		usable:  nw.AssignableCount(),
+		free:    usable.ToIPSet(),
+	}
+}
+
+// Reserve reserves and returns the lowest free address of the pool.
+// ErrorNoFreeAddress is returned if every usable address is reserved.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/29")
+//	pool := netaddr.NewPool(nw)
+//	ip, err := pool.Reserve()
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+//	fmt.Println(ip) // Output: "192.168.1.1"
+func (p *Pool) Reserve() (*IPAddress, error) {
+	if len(p.free) == 0 {
+		return nil, ErrorNoFreeAddress
+	}
+	// free is kept sorted: it starts out as the CIDRs of a single range,
+	// Remove preserves the order of members and Add sorts them.
+	addr := p.free[0].First()
+	p.free.Remove(newNetworkFromIP(p.network.version, addr))
+	return addr, nil
+}
+
+// ReserveSpecific reserves addr. An error is returned if addr is not a usable
+// address of the pool's network or is already reserved.
+//
+// Example usage:
+//
+//	err := pool.ReserveSpecific(netaddr.NewIP("192.168.1.5"))
+//	if err != nil {
+//	    fmt.Println(err)
+//	}
+func (p *Pool) ReserveSpecific(addr *IPAddress) error {
+	if err := p.checkUsable(addr); err != nil {
+		return err
+	}
+	if !p.free.Contains(addr) {
+		return fmt.Errorf("address %s is already reserved", addr)
+	}
+	p.free.Remove(newNetworkFromIP(p.network.version, addr))
+	return nil
+}
+
+// Free releases the reservation of addr, so it can be reserved again. An
+// error is returned if addr is not a usable address of the pool's network or
+// is not reserved.
+//
+// Example usage:
+//
+//	ip, _ := pool.Reserve()
+//	if err := pool.Free(ip); err != nil {
+//	    fmt.Println(err)
+//	}
+func (p *Pool) Free(addr *IPAddress) error {
+	if err := p.checkUsable(addr); err != nil {
+		return err
+	}
+	if p.free.Contains(addr) {
+		return fmt.Errorf("address %s is not reserved", addr)
+	}
+	p.free.Add(newNetworkFromIP(p.network.version, addr))
+	return nil
+}
+
+// ReservedCount returns the number of reserved addresses in the pool.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("192.168.1.0/29")
+//	pool := netaddr.NewPool(nw)
+//	pool.Reserve()
+//	fmt.Println(pool.ReservedCount()) // Output: 1
+func (p *Pool) ReservedCount() *IPNumber {
+	return p.usable.Sub(p.free.Size())
+}
+
+// checkUsable returns an error unless addr is a usable host of the pool's
+// network.
+func (p *Pool) checkUsable(addr *IPAddress) error {
+	first, last := p.network.usableBounds()
+	if addr.Version() != p.network.version || addr.ToInt().LessThan(first) || addr.ToInt().GreaterThan(last) {
+		return fmt.Errorf("address %s is not a usable address of %s", addr, p.network)
+	}
+	return nil
+}
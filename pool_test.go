@@ -0,0 +1,61 @@
+package netaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	t.Parallel()
+
+	pool := NewPool(newTestNetwork(t, "192.168.1.0/29"))
+
+	var reserved []string
+	for {
+		ip, err := pool.Reserve()
+		if err != nil {
+			assert.ErrorIs(t, err, ErrorNoFreeAddress)
+			break
+		}
+		reserved = append(reserved, ip.String())
+	}
+	assert.Equal(t, []string{"192.168.1.1", "192.168.1.2", "192.168.1.3", "192.168.1.4", "192.168.1.5", "192.168.1.6"}, reserved)
+	assert.True(t, NewIPNumber(6).Equal(pool.ReservedCount()))
+
+	// A freed address is handed out again.
+	assert.NoError(t, pool.Free(NewIP("192.168.1.4")))
+	assert.Error(t, pool.Free(NewIP("192.168.1.4")))
+	assert.True(t, NewIPNumber(5).Equal(pool.ReservedCount()))
+	ip, err := pool.Reserve()
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.1.4", ip.String())
+
+	// Network and broadcast addresses are never usable.
+	for _, addr := range []*IPAddress{NewIP("192.168.1.0"), NewIP("192.168.1.7"), NewIP("192.168.1.8"), NewIP("::c0a8:101")} {
+		assert.Error(t, pool.ReserveSpecific(addr), "%s", addr)
+		assert.Error(t, pool.Free(addr), "%s", addr)
+	}
+}
+
+func TestPoolReserveSpecific(t *testing.T) {
+	t.Parallel()
+
+	pool := NewPool(newTestNetwork(t, "2001:db8::/126"))
+
+	assert.NoError(t, pool.ReserveSpecific(NewIP("2001:db8::")))
+	assert.NoError(t, pool.ReserveSpecific(NewIP("2001:db8::2")))
+	assert.Error(t, pool.ReserveSpecific(NewIP("2001:db8::2")))
+	assert.True(t, NewIPNumber(2).Equal(pool.ReservedCount()))
+
+	// Reserve skips addresses reserved explicitly.
+	ip, err := pool.Reserve()
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", ip.String())
+	ip, err = pool.Reserve()
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::3", ip.String())
+
+	_, err = pool.Reserve()
+	assert.ErrorIs(t, err, ErrorNoFreeAddress)
+}
@@ -0,0 +1,149 @@
+package netaddr
+
+import "slices"
+
+// registryEntry is a labelled network of a Registry. rfc optionally names
+// the RFC defining the network, as reported by IPNetwork.RFCClassification.
+type registryEntry struct {
+	network *IPNetwork
+	label   string
+	rfc     string
+}
+
+// Registry classifies addresses by the most specific labelled network
+// containing them. NewRegistry returns a registry holding the IANA
+// special-purpose blocks, which can be extended with custom entries.
+type Registry struct {
+	entries []registryEntry
+}
+
+// specialPurposeBlocks holds the IANA special-purpose address blocks, with
+// the RFC defining each of them, see
+// https://www.iana.org/assignments/iana-ipv4-special-registry and
+// https://www.iana.org/assignments/iana-ipv6-special-registry.
+var specialPurposeBlocks = []registryEntry{
+	{mustNewIPNetwork("0.0.0.0/8"), "This network", "RFC1122 this network"},
+	{mustNewIPNetwork("10.0.0.0/8"), "Private-Use", "RFC1918 private"},
+	{mustNewIPNetwork("100.64.0.0/10"), "Shared Address Space", "RFC6598 CGNAT"},
+	{mustNewIPNetwork("127.0.0.0/8"), "Loopback", "RFC1122 loopback"},
+	{mustNewIPNetwork("169.254.0.0/16"), "Link Local", "RFC3927 link-local"},
+	{mustNewIPNetwork("172.16.0.0/12"), "Private-Use", "RFC1918 private"},
+	{mustNewIPNetwork("192.0.0.0/24"), "IETF Protocol Assignments", "RFC6890 IETF protocol assignments"},
+	{mustNewIPNetwork("192.0.2.0/24"), "Documentation (TEST-NET-1)", "RFC5737 documentation"},
+	{mustNewIPNetwork("192.88.99.0/24"), "Deprecated (6to4 Relay Anycast)", "RFC3068 6to4 relay anycast"},
+	{mustNewIPNetwork("192.168.0.0/16"), "Private-Use", "RFC1918 private"},
+	{mustNewIPNetwork("198.18.0.0/15"), "Benchmarking", "RFC2544 benchmarking"},
+	{mustNewIPNetwork("198.51.100.0/24"), "Documentation (TEST-NET-2)", "RFC5737 documentation"},
+	{mustNewIPNetwork("203.0.113.0/24"), "Documentation (TEST-NET-3)", "RFC5737 documentation"},
+	{mustNewIPNetwork("240.0.0.0/4"), "Reserved", "RFC1112 reserved"},
+	{mustNewIPNetwork("255.255.255.255/32"), "Limited Broadcast", "RFC919 limited broadcast"},
+	{mustNewIPNetwork("::/128"), "Unspecified Address", "RFC4291 unspecified"},
+	{mustNewIPNetwork("::1/128"), "Loopback Address", "RFC4291 loopback"},
+	{mustNewIPNetwork("64:ff9b::/96"), "IPv4-IPv6 Translation", "RFC6052 IPv4-IPv6 translation"},
+	{mustNewIPNetwork("64:ff9b:1::/48"), "IPv4-IPv6 Translation", "RFC8215 IPv4-IPv6 translation"},
+	{mustNewIPNetwork("100::/64"), "Discard-Only Address Block", "RFC6666 discard-only"},
+	{mustNewIPNetwork("2001::/23"), "IETF Protocol Assignments", "RFC2928 IETF protocol assignments"},
+	{mustNewIPNetwork("2001::/32"), "TEREDO", "RFC4380 Teredo"},
+	{mustNewIPNetwork("2001:2::/48"), "Benchmarking", "RFC5180 benchmarking"},
+	{mustNewIPNetwork("2001:db8::/32"), "Documentation", "RFC3849 documentation"},
+	{mustNewIPNetwork("2002::/16"), "6to4", "RFC3056 6to4"},
+	{mustNewIPNetwork("fc00::/7"), "Unique-Local", "RFC4193 unique local"},
+	{mustNewIPNetwork("fe80::/10"), "Link-Local Unicast", "RFC4291 link-local"},
+}
+
+// ianaRegistry backs IPAddress.IsReserved and IPAddress.SpecialPurpose.
+var ianaRegistry = &Registry{entries: specialPurposeBlocks}
+
+// NewRegistry returns a Registry populated with the IANA IPv4 and IPv6
+// special-purpose blocks.
+//
+// Example usage:
+//
+//	lab, _ := netaddr.NewIPNetwork("10.20.0.0/16")
+//	reg := netaddr.NewRegistry()
+//	reg.Add(lab, "Lab")
+//	label, ok := reg.Classify(netaddr.NewIP("10.20.1.1"))
+//	fmt.Println(label, ok) // Output: Lab true
+func NewRegistry() *Registry {
+	return &Registry{entries: append([]registryEntry(nil), specialPurposeBlocks...)}
+}
+
+// Add registers nw under label. Where networks of the same prefix length
+// overlap, the one added last takes precedence.
+func (r *Registry) Add(nw *IPNetwork, label string) {
+	r.entries = append(r.entries, registryEntry{network: nw, label: label})
+}
+
+// Classify returns the label of the most specific registered network
+// containing addr. ok is false if no registered network contains addr.
+//
+// Example usage:
+//
+//	reg := netaddr.NewRegistry()
+//	label, ok := reg.Classify(netaddr.NewIP("100.64.0.1"))
+//	fmt.Println(label, ok) // Output: Shared Address Space true
+func (r *Registry) Classify(addr *IPAddress) (label string, ok bool) {
+	var match *IPNetwork
+	for _, entry := range r.entries {
+		if entry.network.version != addr.Version() || !entry.network.ContainsAddress(addr) {
+			continue
+		}
+		if match == nil || entry.network.Mask.PrefixLen() >= match.Mask.PrefixLen() {
+			match, label = entry.network, entry.label
+		}
+	}
+	return label, match != nil
+}
+
+// IsReserved returns true when ip is within any block of the IANA IPv4 or
+// IPv6 Special-Purpose Address Registries, e.g. 100.64.0.0/10 or
+// 192.0.2.0/24. See SpecialPurpose for the name of the block.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("100.64.0.1")
+//	fmt.Println(ip.IsReserved()) // Output: true
+func (ip *IPAddress) IsReserved() bool {
+	_, ok := ip.SpecialPurpose()
+	return ok
+}
+
+// SpecialPurpose returns the registry name of the most specific IANA
+// special-purpose block containing ip, e.g. "Shared Address Space" for
+// 100.64.0.1. ok is false if ip is not within any special-purpose block.
+//
+// Example usage:
+//
+//	ip := netaddr.NewIP("203.0.113.5")
+//	name, ok := ip.SpecialPurpose()
+//	fmt.Println(name, ok) // Output: Documentation (TEST-NET-3) true
+func (ip *IPAddress) SpecialPurpose() (name string, ok bool) {
+	return ianaRegistry.Classify(ip)
+}
+
+// RFCClassification returns the RFC labels of the IANA special-purpose blocks
+// that nw overlaps, e.g. "RFC1918 private" or "RFC6598 CGNAT", in address
+// order and without duplicates. Blocks that only partially cover nw, e.g.
+// because nw is a supernet of them, are labelled with a " (partial)" suffix.
+// nil is returned if nw overlaps no special-purpose block.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/8")
+//	fmt.Println(nw.RFCClassification()) // Output: [RFC1918 private]
+func (nw *IPNetwork) RFCClassification() []string {
+	var labels []string
+	for _, block := range specialPurposeBlocks {
+		if !nw.Overlaps(block.network) {
+			continue
+		}
+		label := block.rfc
+		if !block.network.IsSupernetOf(nw) {
+			label += " (partial)"
+		}
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
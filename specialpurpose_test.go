@@ -0,0 +1,108 @@
+package netaddr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPAddressSpecialPurpose(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		addr     *IPAddress
+		name     string
+		reserved bool
+	}{
+		{NewIP("100.64.0.1"), "Shared Address Space", true},
+		{NewIP("203.0.113.5"), "Documentation (TEST-NET-3)", true},
+		{NewIP("198.19.255.255"), "Benchmarking", true},
+		{NewIP("240.0.0.1"), "Reserved", true},
+		{NewIP("255.255.255.255"), "Limited Broadcast", true},
+		{NewIP("2001:db8::1"), "Documentation", true},
+		{NewIP("2001:0:4136:e378::1"), "TEREDO", true},
+		{NewIP("2001:4::1"), "IETF Protocol Assignments", true},
+		{NewIP("8.8.8.8"), "", false},
+		{NewIP("2606:4700::1111"), "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.addr.String(), func(t *testing.T) {
+			name, ok := test.addr.SpecialPurpose()
+			assert.Equal(t, test.reserved, ok)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.reserved, test.addr.IsReserved())
+		})
+	}
+}
+
+func TestRegistryClassify(t *testing.T) {
+	t.Parallel()
+
+	lab, _ := NewIPNetwork("10.20.0.0/16")
+	partner, _ := NewIPNetwork("198.51.100.0/24")
+	resolvers, _ := NewIPNetwork("8.8.8.0/24")
+
+	reg := NewRegistry()
+	reg.Add(lab, "Lab")
+	reg.Add(partner, "Partner")
+	reg.Add(resolvers, "Resolvers")
+
+	var tests = []struct {
+		addr  *IPAddress
+		label string
+		ok    bool
+	}{
+		{NewIP("10.20.1.1"), "Lab", true},
+		{NewIP("10.21.1.1"), "Private-Use", true},
+		{NewIP("198.51.100.7"), "Partner", true},
+		{NewIP("8.8.8.8"), "Resolvers", true},
+		{NewIP("100.64.0.1"), "Shared Address Space", true},
+		{NewIP("1.1.1.1"), "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.addr.String(), func(t *testing.T) {
+			label, ok := reg.Classify(test.addr)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.label, label)
+		})
+	}
+
+	// Custom entries do not leak into the IANA classification.
+	assert.False(t, NewIP("8.8.8.8").IsReserved())
+	name, _ := NewIP("198.51.100.7").SpecialPurpose()
+	assert.Equal(t, "Documentation (TEST-NET-2)", name)
+}
+
+func TestIPNetworkRFCClassification(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		cidr     string
+		expected []string
+	}{
+		{"10.0.0.0/8", []string{"RFC1918 private"}},
+		{"10.1.2.0/24", []string{"RFC1918 private"}},
+		{"100.64.0.0/10", []string{"RFC6598 CGNAT"}},
+		{"192.168.0.0/15", []string{"RFC1918 private (partial)"}},
+		{"198.0.0.0/8", []string{"RFC2544 benchmarking (partial)", "RFC5737 documentation (partial)"}},
+		{"192.0.0.0/16", []string{"RFC6890 IETF protocol assignments (partial)", "RFC5737 documentation (partial)"}},
+		{"2001:db8:1::/48", []string{"RFC3849 documentation"}},
+		{"64:ff9b:1::/64", []string{"RFC8215 IPv4-IPv6 translation"}},
+		{"2001:2::/48", []string{"RFC2928 IETF protocol assignments", "RFC5180 benchmarking"}},
+		{"8.8.8.0/24", nil},
+		{"224.0.0.0/4", nil},
+	}
+
+	// Every special-purpose block carries an RFC label.
+	for _, block := range specialPurposeBlocks {
+		assert.NotEmpty(t, block.rfc, "%s", block.network)
+	}
+
+	for _, test := range tests {
+		t.Run(test.cidr, func(t *testing.T) {
+			assert.Equal(t, test.expected, newTestNetwork(t, test.cidr).RFCClassification())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package netaddr
+
+import "sync"
+
+// SyncIPSet is an IPSet that is safe for concurrent use by multiple
+// goroutines. Reads take a shared lock and mutations an exclusive one. The
+// zero value is an empty set ready to use. A SyncIPSet must not be copied
+// after first use.
+type SyncIPSet struct {
+	mu  sync.RWMutex
+	set IPSet
+}
+
+// NewSyncIPSet returns a SyncIPSet holding the members of set, merged as by
+// IPSet.Add.
+//
+// Example usage:
+//
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/24")
+//	set := netaddr.NewSyncIPSet(netaddr.IPSet{nw})
+func NewSyncIPSet(set IPSet) *SyncIPSet {
+	return &SyncIPSet{set: CidrMerge(set...)}
+}
+
+// Add adds an IP network to the set, see IPSet.Add.
+//
+// Example usage:
+//
+//	set := &netaddr.SyncIPSet{}
+//	set.Add(nw)
+func (s *SyncIPSet) Add(nw *IPNetwork) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(nw)
+}
+
+// Remove removes the addresses of an IP network from the set, see
+// IPSet.Remove.
+//
+// Example usage:
+//
+//	set.Remove(nw)
+func (s *SyncIPSet) Remove(nw *IPNetwork) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Remove(nw)
+}
+
+// Contains returns true when addr is within any member of the set.
+//
+// Example usage:
+//
+//	fmt.Println(set.Contains(netaddr.NewIP("10.0.0.1")))
+func (s *SyncIPSet) Contains(addr *IPAddress) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(addr)
+}
+
+// Snapshot returns a copy of the members of the set at the time of the call.
+// Later changes to the set do not affect the returned IPSet, nor the other
+// way around.
+//
+// Example usage:
+//
+//	fmt.Println(set.Snapshot())
+func (s *SyncIPSet) Snapshot() IPSet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append(IPSet{}, s.set...)
+}
@@ -0,0 +1,41 @@
+package netaddr
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncIPSet(t *testing.T) {
+	t.Parallel()
+
+	set := NewSyncIPSet(IPSet{newTestNetwork(t, "10.0.1.0/24")})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 256; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			set.Add(newTestNetwork(t, fmt.Sprintf("10.0.0.%d/32", i)))
+			set.Contains(NewIP("10.0.0.1"))
+			set.Remove(newTestNetwork(t, fmt.Sprintf("10.0.1.%d/32", i)))
+			_ = set.Snapshot().String()
+		}()
+	}
+	wg.Wait()
+
+	snapshot := set.Snapshot()
+	assert.Equal(t, []string{"10.0.0.0/24"}, networkStrings(snapshot))
+	assert.True(t, set.Contains(NewIP("10.0.0.255")))
+	assert.False(t, set.Contains(NewIP("10.0.1.0")))
+
+	// The snapshot is independent of the set.
+	snapshot.Add(newTestNetwork(t, "10.0.1.0/24"))
+	assert.False(t, set.Contains(NewIP("10.0.1.0")))
+
+	var zero SyncIPSet
+	zero.Add(newTestNetwork(t, "2001:db8::/32"))
+	assert.True(t, zero.Contains(NewIP("2001:db8::1")))
+}
@@ -0,0 +1,104 @@
+package netaddr
+
+// PrefixTrie maps IP networks to values and finds the most specific network
+// containing an address, i.e. a longest-prefix match, in time proportional to
+// the address length rather than the number of networks. IPv4 and IPv6
+// networks are held in separate binary tries over the address bits.
+//
+// The zero value is an empty trie ready to use.
+type PrefixTrie struct {
+	v4 *trieNode
+	v6 *trieNode
+}
+
+// trieNode is a node in a PrefixTrie, reached by following the bits of a
+// prefix from the root. Nodes for inserted networks hold the network and its
+// value.
+type trieNode struct {
+	children [2]*trieNode
+	nw       *IPNetwork
+	value    any
+}
+
+// NewPrefixTrie returns an empty PrefixTrie.
+//
+// Example usage:
+//
+//	trie := netaddr.NewPrefixTrie()
+func NewPrefixTrie() *PrefixTrie {
+	return &PrefixTrie{}
+}
+
+// Insert adds nw to the trie with the associated value, replacing the value of
+// an equal network that was inserted previously.
+//
+// Example usage:
+//
+//	trie := netaddr.NewPrefixTrie()
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/8")
+//	trie.Insert(nw, "internal")
+func (t *PrefixTrie) Insert(nw *IPNetwork, value any) {
+	root := &t.v4
+	if nw.version == IPv6 {
+		root = &t.v6
+	}
+	if *root == nil {
+		*root = &trieNode{}
+	}
+
+	node := *root
+	bytes := *nw.First().IP
+	for i := 0; i < nw.Mask.PrefixLen(); i++ {
+		bit := addressBit(bytes, i)
+		if node.children[bit] == nil {
+			node.children[bit] = &trieNode{}
+		}
+		node = node.children[bit]
+	}
+	node.nw = nw
+	node.value = value
+}
+
+// Lookup returns the value and network of the most specific network in the
+// trie that contains addr. ok is false if no network contains it, or if addr
+// is not a valid IPv4 or IPv6 address.
+//
+// Example usage:
+//
+//	trie := netaddr.NewPrefixTrie()
+//	nw, _ := netaddr.NewIPNetwork("10.0.0.0/8")
+//	trie.Insert(nw, "internal")
+//	value, match, ok := trie.Lookup(netaddr.NewIP("10.1.2.3"))
+//	fmt.Println(value, match, ok) // Output: internal 10.0.0.0/8 true
+func (t *PrefixTrie) Lookup(addr *IPAddress) (value any, nw *IPNetwork, ok bool) {
+	if addr == nil {
+		return nil, nil, false
+	}
+	var node *trieNode
+	switch addr.Version() {
+	case IPv4:
+		node = t.v4
+	case IPv6:
+		node = t.v6
+	default:
+		return nil, nil, false
+	}
+
+	bytes := *addr.IP
+	for i := 0; node != nil; i++ {
+		if node.nw != nil {
+			value, nw, ok = node.value, node.nw, true
+		}
+		if i == len(bytes)*8 {
+			break
+		}
+		node = node.children[addressBit(bytes, i)]
+	}
+	return value, nw, ok
+}
+
+// addressBit returns bit i of the address bytes, counting from the most
+// significant bit.
+func addressBit(bytes []byte, i int) byte {
+	return (bytes[i/8] >> (7 - i%8)) & 1
+}
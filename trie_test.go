@@ -0,0 +1,96 @@
+package netaddr
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixTrie(t *testing.T) {
+	t.Parallel()
+
+	trie := NewPrefixTrie()
+	trie.Insert(newTestNetwork(t, "10.0.0.0/8"), "a")
+	trie.Insert(newTestNetwork(t, "10.0.1.0/24"), "b")
+	trie.Insert(newTestNetwork(t, "10.0.1.0/26"), "c")
+	trie.Insert(newTestNetwork(t, "0.0.0.0/0"), "default")
+	trie.Insert(newTestNetwork(t, "2001:db8::/32"), "v6")
+	trie.Insert(newTestNetwork(t, "2001:db8::1/128"), "v6 host")
+	trie.Insert(newTestNetwork(t, "10.0.1.0/24"), "b2")
+
+	var tests = []struct {
+		addr  *IPAddress
+		value any
+		nw    string
+		ok    bool
+	}{
+		{NewIP("10.0.1.1"), "c", "10.0.1.0/26", true},
+		{NewIP("10.0.1.200"), "b2", "10.0.1.0/24", true},
+		{NewIP("10.2.0.1"), "a", "10.0.0.0/8", true},
+		{NewIP("192.168.0.1"), "default", "0.0.0.0/0", true},
+		{NewIP("2001:db8::1"), "v6 host", "2001:db8::1/128", true},
+		{NewIP("2001:db8::2"), "v6", "2001:db8::/32", true},
+		{NewIP("2001:db9::1"), nil, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.addr.String(), func(t *testing.T) {
+			value, nw, ok := trie.Lookup(test.addr)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.value, value)
+			if test.ok {
+				assert.Equal(t, test.nw, nw.String())
+			} else {
+				assert.Nil(t, nw)
+			}
+		})
+	}
+
+	// Invalid addresses carry no version and must not fall through to the
+	// IPv4 default route.
+	for _, addr := range []*IPAddress{NewIP("garbage"), {}, nil} {
+		value, nw, ok := trie.Lookup(addr)
+		assert.False(t, ok)
+		assert.Nil(t, value)
+		assert.Nil(t, nw)
+	}
+
+	var empty PrefixTrie
+	_, _, ok := empty.Lookup(NewIP("10.0.0.1"))
+	assert.False(t, ok)
+}
+
+// BenchmarkLongestPrefixMatch compares PrefixTrie lookups with a linear scan
+// using SmallestMatchingCIDR over the same set of prefixes.
+func BenchmarkLongestPrefixMatch(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	cidrs := make([]*IPNetwork, 0, 10000)
+	trie := NewPrefixTrie()
+	for len(cidrs) < cap(cidrs) {
+		nw, err := NewIPNetwork(fmt.Sprintf("%d.%d.%d.0/%d", rng.Intn(256), rng.Intn(256), rng.Intn(256), 8+rng.Intn(17)))
+		if err != nil {
+			b.Fatal(err)
+		}
+		cidrs = append(cidrs, nw)
+		trie.Insert(nw, nil)
+	}
+	addrs := make([]*IPAddress, 1000)
+	for i := range addrs {
+		addrs[i] = NewIP(fmt.Sprintf("%d.%d.%d.%d", rng.Intn(256), rng.Intn(256), rng.Intn(256), rng.Intn(256)))
+	}
+
+	b.Run("trie", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			trie.Lookup(addrs[i%len(addrs)])
+		}
+	})
+	b.Run("linear", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			SmallestMatchingCIDR(addrs[i%len(addrs)], cidrs)
+		}
+	})
+}